@@ -10,8 +10,37 @@ import (
 const (
 	requestTokenQueryParam = "request_token"
 	redirectUriQueryParam  = "redirect_uri"
+	mobileQueryParam       = "mobile"
+	forceQueryParam        = "force"
 )
 
+// force
+
+type Force string
+
+const (
+	ForceSignup Force = "signup"
+	ForceLogin  Force = "login"
+)
+
+// AuthUrlOption adds optional query parameters to the authorization url.
+type AuthUrlOption func(q url.Values)
+
+// WithMobile asks Pocket to show the mobile optimized authorization page.
+// Use it when the url is opened inside a webview.
+func WithMobile() AuthUrlOption {
+	return func(q url.Values) {
+		q.Set(mobileQueryParam, "1")
+	}
+}
+
+// WithForce forces the signup or the login page to be shown.
+func WithForce(f Force) AuthUrlOption {
+	return func(q url.Values) {
+		q.Set(forceQueryParam, string(f))
+	}
+}
+
 type (
 	codeRequest struct {
 		ConsumerKey string `json:"consumer_key"`
@@ -93,8 +122,12 @@ func (p *Pocket) AuthUser(ctx context.Context) error {
 	return nil
 }
 
-func (p *Pocket) MakeAuthUrl(redirectUri string) (string, error) {
-	u, err := url.Parse(p.baseURL)
+func (p *Pocket) MakeAuthUrl(redirectUri string, opts ...AuthUrlOption) (string, error) {
+	if p.requestToken == "" {
+		return "", ErrEmptyRequestToken
+	}
+
+	u, err := url.Parse(p.authBaseURL)
 	if err != nil {
 		return "", fmt.Errorf("error while building auth url: %w", err)
 	}
@@ -102,6 +135,9 @@ func (p *Pocket) MakeAuthUrl(redirectUri string) (string, error) {
 	q := u.Query()
 	q.Add(requestTokenQueryParam, p.requestToken)
 	q.Add(redirectUriQueryParam, redirectUri)
+	for _, opt := range opts {
+		opt(q)
+	}
 	u.RawQuery = q.Encode()
 
 	return u.String(), nil
@@ -379,9 +379,10 @@ func TestPocket_BuildAuthUrl(t *testing.T) {
 		consumerKey:  consumerKey,
 		requestToken: requestToken,
 		baseURL:      baseURL,
+		authBaseURL:  authBaseURL,
 	}
 
-	u, err := url.Parse(p.baseURL)
+	u, err := url.Parse(p.authBaseURL)
 	require.NoError(t, err)
 	u.Path = path.Join(u.Path, authPath)
 	u.RawQuery = fmt.Sprintf(
@@ -393,3 +394,76 @@ func TestPocket_BuildAuthUrl(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, u.String(), bu)
 }
+
+func TestPocket_BuildAuthUrlOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []AuthUrlOption
+		expParams url.Values
+	}{
+		{
+			name: "Mobile",
+			opts: []AuthUrlOption{WithMobile()},
+			expParams: url.Values{
+				mobileQueryParam: {"1"},
+			},
+		},
+		{
+			name: "Force signup",
+			opts: []AuthUrlOption{WithForce(ForceSignup)},
+			expParams: url.Values{
+				forceQueryParam: {string(ForceSignup)},
+			},
+		},
+		{
+			name: "Mobile and force login",
+			opts: []AuthUrlOption{WithMobile(), WithForce(ForceLogin)},
+			expParams: url.Values{
+				mobileQueryParam: {"1"},
+				forceQueryParam:  {string(ForceLogin)},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := New(consumerKey).WithBaseUrl("http://proxy.local/pocket")
+			p.SetRequestToken(requestToken)
+
+			bu, err := p.MakeAuthUrl(redirectURL, tc.opts...)
+			require.NoError(t, err)
+
+			u, err := url.Parse(bu)
+			require.NoError(t, err)
+			require.Equal(t, "getpocket.com", u.Host)
+			require.Equal(t, authPath, u.Path)
+
+			q := u.Query()
+			require.Equal(t, requestToken, q.Get(requestTokenQueryParam))
+			require.Equal(t, redirectURL, q.Get(redirectUriQueryParam))
+			for k, v := range tc.expParams {
+				require.Equal(t, v, q[k])
+			}
+		})
+	}
+}
+
+func TestPocket_BuildAuthUrlCustomAuthBase(t *testing.T) {
+	p := New(consumerKey).WithAuthBaseUrl("https://auth.example.com/base")
+	p.SetRequestToken(requestToken)
+
+	bu, err := p.MakeAuthUrl(redirectURL)
+	require.NoError(t, err)
+
+	u, err := url.Parse(bu)
+	require.NoError(t, err)
+	require.Equal(t, "auth.example.com", u.Host)
+	require.Equal(t, path.Join("/base", authPath), u.Path)
+}
+
+func TestPocket_BuildAuthUrlEmptyRequestToken(t *testing.T) {
+	p := New(consumerKey)
+
+	_, err := p.MakeAuthUrl(redirectURL)
+	require.ErrorIs(t, err, ErrEmptyRequestToken)
+}
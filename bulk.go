@@ -0,0 +1,207 @@
+package pocket
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBulkChunkSize = 100
+)
+
+// BulkQuery selects items with Retrieve and client side predicates.
+// Zero fields are not checked.
+type BulkQuery struct {
+	Input         RetrieveInput
+	OlderThan     time.Duration // added more than OlderThan ago
+	NewerThan     time.Duration // added less than NewerThan ago
+	MinWords      int
+	MaxWords      int
+	MinTimeToRead int // minutes
+	MaxTimeToRead int // minutes
+	Match         func(item RetrieveListItem) bool
+}
+
+func (q *BulkQuery) matches(item RetrieveListItem, now time.Time) bool {
+	added := item.TimeAddedAt()
+	switch {
+	case item.Status == ItemStatusDeleted:
+		return false
+	case q.OlderThan != 0 && !added.Before(now.Add(-q.OlderThan)):
+		return false
+	case q.NewerThan != 0 && added.Before(now.Add(-q.NewerThan)):
+		return false
+	case q.MinWords != 0 && item.Words() < q.MinWords:
+		return false
+	case q.MaxWords != 0 && item.Words() > q.MaxWords:
+		return false
+	case q.MinTimeToRead != 0 && item.TimeToRead < q.MinTimeToRead:
+		return false
+	case q.MaxTimeToRead != 0 && item.TimeToRead > q.MaxTimeToRead:
+		return false
+	case q.Match != nil && !q.Match(item):
+		return false
+	}
+	return true
+}
+
+// BulkProgress is called after every Modify chunk.
+type BulkProgress func(done, total int)
+
+type BulkResult struct {
+	Planned   Actions
+	Responses []*ModifyResponse
+	Done      int // number of sent actions
+}
+
+// Bulk applies one action to every item selected by the query.
+type Bulk struct {
+	ctx       context.Context
+	client    Client
+	query     BulkQuery
+	dryRun    bool
+	chunkSize int
+	progress  BulkProgress
+}
+
+// Bulk starts a bulk operation, e.g.
+//
+//	res, err := p.Bulk(ctx, pocket.BulkQuery{
+//		Input:     pocket.RetrieveInput{Domain: "example.com"},
+//		OlderThan: 90 * 24 * time.Hour,
+//	}).Archive()
+func (p *Pocket) Bulk(ctx context.Context, q BulkQuery) *Bulk {
+	return NewBulk(ctx, p, q)
+}
+
+func NewBulk(ctx context.Context, c Client, q BulkQuery) *Bulk {
+	return &Bulk{
+		ctx:       ctx,
+		client:    c,
+		query:     q,
+		chunkSize: defaultBulkChunkSize,
+	}
+}
+
+// DryRun makes the operation only return the planned actions.
+func (b *Bulk) DryRun() *Bulk {
+	b.dryRun = true
+	return b
+}
+
+// WithChunkSize sets the number of actions sent with one Modify call.
+func (b *Bulk) WithChunkSize(n int) *Bulk {
+	if n > 0 {
+		b.chunkSize = n
+	}
+	return b
+}
+
+func (b *Bulk) WithProgress(fn BulkProgress) *Bulk {
+	b.progress = fn
+	return b
+}
+
+func (b *Bulk) Archive() (*BulkResult, error) {
+	return b.do(func(id int64, t int64) interface{} {
+		return &ActionArchive{Action: ActionArchiveType, ItemID: id, Time: t}
+	})
+}
+
+func (b *Bulk) Readd() (*BulkResult, error) {
+	return b.do(func(id int64, t int64) interface{} {
+		return &ActionReadd{Action: ActionReaddType, ItemID: id, Time: t}
+	})
+}
+
+func (b *Bulk) Favorite() (*BulkResult, error) {
+	return b.do(func(id int64, t int64) interface{} {
+		return &ActionFavorite{Action: ActionFavoriteType, ItemID: id, Time: t}
+	})
+}
+
+func (b *Bulk) Unfavorite() (*BulkResult, error) {
+	return b.do(func(id int64, t int64) interface{} {
+		return &ActionUnfavorite{Action: ActionUnfavoriteType, ItemID: id, Time: t}
+	})
+}
+
+func (b *Bulk) Delete() (*BulkResult, error) {
+	return b.do(func(id int64, t int64) interface{} {
+		return &ActionDelete{Action: ActionDeleteType, ItemID: id, Time: t}
+	})
+}
+
+func (b *Bulk) AddTags(tags ...string) (*BulkResult, error) {
+	joined := strings.Join(tags, ",")
+	return b.do(func(id int64, t int64) interface{} {
+		return &ActionTagsAdd{Action: ActionTagsAddType, ItemID: id, Tags: joined, Time: t}
+	})
+}
+
+func (b *Bulk) RemoveTags(tags ...string) (*BulkResult, error) {
+	joined := strings.Join(tags, ",")
+	return b.do(func(id int64, t int64) interface{} {
+		return &ActionTagsRemove{Action: ActionTagsRemoveType, ItemID: id, Tags: joined, Time: t}
+	})
+}
+
+// Items returns the items selected by the query sorted by sort_id.
+func (b *Bulk) Items() ([]RetrieveListItem, error) {
+	all, err := RetrieveAll(b.ctx, b.client, &b.query.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var items []RetrieveListItem
+	for _, item := range all {
+		if b.query.matches(item, now) {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (b *Bulk) do(build func(id int64, t int64) interface{}) (*BulkResult, error) {
+	items, err := b.Items()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	res := &BulkResult{}
+	for _, item := range items {
+		id, err := strconv.ParseInt(item.ItemID, 10, 64)
+		if err != nil {
+			continue
+		}
+		res.Planned = append(res.Planned, build(id, now))
+	}
+
+	if b.dryRun {
+		return res, nil
+	}
+
+	for start := 0; start < len(res.Planned); start += b.chunkSize {
+		end := start + b.chunkSize
+		if end > len(res.Planned) {
+			end = len(res.Planned)
+		}
+
+		resp, err := b.client.Modify(b.ctx, res.Planned[start:end])
+		if err != nil {
+			return res, err
+		}
+		res.Responses = append(res.Responses, resp)
+		res.Done = end
+
+		if b.progress != nil {
+			b.progress(res.Done, len(res.Planned))
+		}
+	}
+
+	return res, nil
+}
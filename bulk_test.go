@@ -0,0 +1,128 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func bulkHandler(t *testing.T, total int, modified *[]int) http.HandlerFunc {
+	old := time.Now().Add(-100 * 24 * time.Hour).Unix()
+	fresh := time.Now().Unix()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		if r.URL.Path == modifyPath {
+			req := struct {
+				Actions []ActionFields `json:"actions"`
+			}{}
+			require.NoError(t, json.Unmarshal(data, &req))
+			*modified = append(*modified, len(req.Actions))
+			_, err = w.Write([]byte(`{"status":1}`))
+			require.NoError(t, err)
+			return
+		}
+
+		req := retrieveRequest{}
+		require.NoError(t, json.Unmarshal(data, &req))
+		require.Equal(t, "example.com", req.Domain)
+
+		if int(req.Offset) >= total {
+			// Pocket sends an empty array instead of an empty object
+			_, err = w.Write([]byte(`{"status":2,"list":[]}`))
+			require.NoError(t, err)
+			return
+		}
+
+		list := map[string]RetrieveListItem{}
+		for i := int(req.Offset); i < int(req.Offset+req.Count) && i < total; i++ {
+			added := old
+			if i%2 == 1 {
+				added = fresh
+			}
+			id := strconv.Itoa(i + 1)
+			list[id] = RetrieveListItem{
+				ItemID:    id,
+				SortID:    i,
+				TimeAdded: strconv.FormatInt(added, 10),
+				WordCount: fmt.Sprint(i * 100),
+			}
+		}
+		data, err = json.Marshal(RetrieveResponse{Status: successStatus, List: list})
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+	}
+}
+
+func TestPocket_BulkDryRun(t *testing.T) {
+	var modified []int
+	srv := httptest.NewServer(bulkHandler(t, 10, &modified))
+	defer srv.Close()
+
+	p := New(consumerKey).WithBaseUrl(srv.URL)
+	res, err := p.Bulk(context.Background(), BulkQuery{
+		Input:     RetrieveInput{Domain: "example.com"},
+		OlderThan: 90 * 24 * time.Hour,
+		MinWords:  300,
+	}).DryRun().AddTags("old", "long")
+	require.NoError(t, err)
+
+	require.Empty(t, modified)
+	require.Len(t, res.Planned, 3)
+	require.Equal(t, &ActionTagsAdd{
+		Action: ActionTagsAddType,
+		ItemID: 5,
+		Tags:   "old,long",
+		Time:   res.Planned[0].(*ActionTagsAdd).Time,
+	}, res.Planned[0])
+}
+
+func TestPocket_BulkArchive(t *testing.T) {
+	var modified []int
+	srv := httptest.NewServer(bulkHandler(t, 250, &modified))
+	defer srv.Close()
+
+	var progress []int
+	p := New(consumerKey).WithBaseUrl(srv.URL)
+	res, err := p.Bulk(context.Background(), BulkQuery{
+		Input:     RetrieveInput{Domain: "example.com"},
+		OlderThan: 90 * 24 * time.Hour,
+	}).WithChunkSize(50).WithProgress(func(done, total int) {
+		require.Equal(t, 125, total)
+		progress = append(progress, done)
+	}).Archive()
+	require.NoError(t, err)
+
+	require.Len(t, res.Planned, 125)
+	require.Equal(t, 125, res.Done)
+	require.Equal(t, []int{50, 50, 25}, modified)
+	require.Equal(t, []int{50, 100, 125}, progress)
+	require.IsType(t, &ActionArchive{}, res.Planned[0])
+}
+
+func TestPocket_BulkEmptyLastPage(t *testing.T) {
+	for _, total := range []int{0, 200} {
+		var modified []int
+		srv := httptest.NewServer(bulkHandler(t, total, &modified))
+
+		p := New(consumerKey).WithBaseUrl(srv.URL)
+		items, err := p.Bulk(context.Background(), BulkQuery{
+			Input: RetrieveInput{Domain: "example.com"},
+		}).Items()
+		srv.Close()
+
+		require.NoError(t, err, total)
+		require.Len(t, items, total)
+	}
+}
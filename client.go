@@ -0,0 +1,28 @@
+package pocket
+
+import (
+	"context"
+	"time"
+)
+
+// Client is the API of Pocket. Depend on it instead of *Pocket to mock the SDK in tests,
+// see the pocketmock package.
+type Client interface {
+	Add(ctx context.Context, ad *AddInput) (*AddResponse, error)
+	Retrieve(ctx context.Context, rd *RetrieveInput) (*RetrieveResponse, error)
+	Modify(ctx context.Context, actions Actions) (*ModifyResponse, error)
+	Watch(ctx context.Context, interval time.Duration, filter *RetrieveInput) <-chan Event
+
+	GenerateRequestToken(ctx context.Context, redirectURI string) (*AuthAppResponse, error)
+	GenerateAccessToken(ctx context.Context) (*AuthUserResponse, error)
+	AuthApp(ctx context.Context, redirectURI string) error
+	AuthUser(ctx context.Context) error
+	MakeAuthUrl(redirectUri string, opts ...AuthUrlOption) (string, error)
+
+	GetRequestToken() string
+	SetRequestToken(requestToken string)
+	GetAccessToken() string
+	SetAccessToken(at string)
+}
+
+var _ Client = (*Pocket)(nil)
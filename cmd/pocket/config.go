@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const configFile = "config.json"
+
+type config struct {
+	ConsumerKey string `json:"consumer_key,omitempty"`
+	AccessToken string `json:"access_token,omitempty"`
+	Username    string `json:"username,omitempty"`
+}
+
+// defaultConfigDir returns the pocket directory in the user config directory, e.g. ~/.config/pocket.
+func defaultConfigDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("error while finding config directory: %w", err)
+	}
+	return filepath.Join(dir, "pocket"), nil
+}
+
+// loadConfig reads the config of the directory, a missing file gives an empty config.
+func loadConfig(dir string) (*config, error) {
+	cfg := &config{}
+
+	data, err := os.ReadFile(filepath.Join(dir, configFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error while reading config: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("error while unmarshalling config: %w", err)
+	}
+	return cfg, nil
+}
+
+// saveConfig writes the config readable only by the user, it holds the access token.
+func saveConfig(dir string, cfg *config) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("error while creating config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error while marshalling config: %w", err)
+	}
+
+	path := filepath.Join(dir, configFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("error while writing config: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("error while replacing config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "pocket")
+
+	cfg, err := loadConfig(dir)
+	require.NoError(t, err)
+	require.Equal(t, &config{}, cfg)
+
+	want := &config{ConsumerKey: "key", AccessToken: "token", Username: "user"}
+	require.NoError(t, saveConfig(dir, want))
+
+	info, err := os.Stat(filepath.Join(dir, configFile))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	cfg, err = loadConfig(dir)
+	require.NoError(t, err)
+	require.Equal(t, want, cfg)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, configFile), []byte("{"), 0o600))
+	_, err = loadConfig(dir)
+	require.Error(t, err)
+}
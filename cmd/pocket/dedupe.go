@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/dedupe"
+)
+
+// dedupe prints groups of items saved more than once, and merges them with -apply.
+// It fails if Pocket rejected any of the merge actions.
+func (a *app) dedupe(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("dedupe", flag.ContinueOnError)
+	apply := fs.Bool("apply", false, "merge the duplicates, only print them if false")
+	titles := fs.Float64("titles", 0, "share of common title words of duplicates, e.g. 0.9; titles are not compared if zero")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usageError("unexpected arguments")
+	}
+	if *titles < 0 || *titles > 1 {
+		return usageError("titles must be between 0 and 1")
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	items, err := pocket.RetrieveAll(ctx, c, &pocket.RetrieveInput{
+		State:      pocket.All,
+		DetailType: pocket.Complete,
+	})
+	if err != nil {
+		return err
+	}
+
+	groups := dedupe.Find(items, dedupe.Options{TitleSimilarity: *titles})
+	r, err := dedupe.Merge(ctx, c, groups, !*apply)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(a.stdout, r.String()); err != nil {
+		return err
+	}
+	// deletes are skipped only when merging failed
+	if len(r.Failed) != 0 {
+		return fmt.Errorf("%d of %d actions failed", len(r.Failed), r.Sent)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/pocketmock"
+	"github.com/stretchr/testify/require"
+)
+
+func dedupeMock() *pocketmock.MockClient {
+	return &pocketmock.MockClient{
+		RetrieveFunc: func(ctx context.Context, rd *pocket.RetrieveInput) (*pocket.RetrieveResponse, error) {
+			return &pocket.RetrieveResponse{List: map[string]pocket.RetrieveListItem{
+				"1": {SortID: 0, TimeAdded: "200", ResolvedURL: "https://example.com/a?utm_source=x"},
+				"2": {SortID: 1, TimeAdded: "100", ResolvedURL: "https://example.com/a"},
+				"3": {SortID: 2, TimeAdded: "300", ResolvedURL: "https://example.com/b"},
+			}}, nil
+		},
+		ModifyFunc: func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+			return &pocket.ModifyResponse{Status: 1}, nil
+		},
+	}
+}
+
+func TestApp_Dedupe(t *testing.T) {
+	ta := newTestApp(t, dedupeMock())
+
+	require.Equal(t, 0, ta.run(context.Background(), []string{"dedupe"}))
+	require.Equal(t, `keep 2 https://example.com/a
+  delete 1 https://example.com/a?utm_source=x
+dry run, 1 actions not sent
+`, ta.stdout.String())
+	require.Empty(t, ta.mock.ModifyCalls())
+
+	ta = newTestApp(t, dedupeMock())
+	require.Equal(t, 0, ta.run(context.Background(), []string{"dedupe", "-apply"}))
+	require.Len(t, ta.mock.ModifyCalls(), 1)
+	f, err := pocket.ParseAction(ta.mock.ModifyCalls()[0].Actions[0])
+	require.NoError(t, err)
+	require.Equal(t, pocket.ActionDeleteType, f.Action)
+	require.Equal(t, int64(1), f.ItemID)
+
+	ta = newTestApp(t, dedupeMock())
+	ta.mock.ModifyFunc = func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+		return &pocket.ModifyResponse{Status: 1, ActionResult: []interface{}{false}}, nil
+	}
+	require.Equal(t, 1, ta.run(context.Background(), []string{"dedupe", "-apply"}))
+	require.Contains(t, ta.stdout.String(), "delete of item 1 failed\n1 actions sent, 1 failed\n")
+	require.Contains(t, ta.stderr.String(), "1 of 1 actions failed")
+
+	ta = newTestApp(t, dedupeMock())
+	require.Equal(t, 2, ta.run(context.Background(), []string{"dedupe", "-titles", "2"}))
+}
@@ -0,0 +1,329 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/query"
+)
+
+const maxTitleWidth = 60
+
+func (a *app) add(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	tags := fs.String("tags", "", "comma separated tags")
+	title := fs.String("title", "", "title used if Pocket can't find one")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageError("add takes one url")
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	res, err := c.Add(ctx, &pocket.AddInput{Url: positional[0], Title: *title, Tags: *tags})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "Added %s %s\n", res.Item.ItemID, positional[0])
+	return nil
+}
+
+func (a *app) list(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	state := fs.String("state", "", "unread, archive or all")
+	favorite := fs.String("favorite", "", "0 for not favorite items, 1 for favorite items")
+	tag := fs.String("tag", "", "tag name or _untagged_")
+	contentType := fs.String("type", "", "article, video or image")
+	sort := fs.String("sort", "", "newest, oldest, title or site")
+	detail := fs.String("detail", "", "simple or complete")
+	search := fs.String("search", "", "search in titles and urls")
+	domain := fs.String("domain", "", "items of the domain")
+	since := fs.Int64("since", 0, "items changed since the unix time")
+	count := fs.Int64("count", 0, "number of items, all if zero")
+	offset := fs.Int64("offset", 0, "offset of the first item, used with -count")
+	q := fs.String("query", "", "query, e.g. 'tag:go is:unread words>1000'")
+	asJSON := fs.Bool("json", false, "print items as JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usageError("unexpected arguments")
+	}
+
+	in := &pocket.RetrieveInput{}
+	var parsed *query.Query
+	if *q != "" {
+		parsed, err = query.Parse(*q)
+		if err != nil {
+			return usageError(err.Error())
+		}
+		in = parsed.RetrieveInput()
+	}
+
+	// flags override the filters of the query
+	var flagErr error
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "state":
+			in.State = pocket.State(*state)
+		case "favorite":
+			fav, err := strconv.Atoi(*favorite)
+			if err != nil || (fav != 0 && fav != 1) {
+				flagErr = usageError("favorite must be 0 or 1")
+				return
+			}
+			v := pocket.Favorite(fav)
+			in.Favorite = &v
+		case "tag":
+			in.Tag = *tag
+		case "type":
+			in.ContentType = pocket.ContentType(*contentType)
+		case "sort":
+			in.Sort = pocket.Sort(*sort)
+		case "detail":
+			in.DetailType = pocket.DetailType(*detail)
+		case "search":
+			in.Search = *search
+		case "domain":
+			in.Domain = *domain
+		case "since":
+			in.Since = since
+		case "count":
+			in.Count = *count
+		case "offset":
+			in.Offset = *offset
+		}
+	})
+	if flagErr != nil {
+		return flagErr
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	items, err := pocket.RetrieveAll(ctx, c, in)
+	if err != nil {
+		return err
+	}
+	if parsed != nil {
+		items = parsed.Filter(items)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		if items == nil {
+			items = []pocket.RetrieveListItem{}
+		}
+		return enc.Encode(items)
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tFLAGS\tADDED\tTTR\tDOMAIN\tTITLE")
+	for _, item := range items {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			item.ItemID,
+			itemFlags(item),
+			formatDate(item.TimeAddedAt()),
+			formatTimeToRead(item.TimeToRead),
+			item.Domain(),
+			truncate(itemTitle(item), maxTitleWidth),
+		)
+	}
+	return tw.Flush()
+}
+
+// itemAction returns the command sending the action for every item id in the arguments.
+func itemAction(action pocket.ActionType) func(a *app, ctx context.Context, args []string) error {
+	return func(a *app, ctx context.Context, args []string) error {
+		if len(args) == 0 {
+			return usageError("no item ids")
+		}
+
+		now := time.Now().Unix()
+		actions := make(pocket.Actions, 0, len(args))
+		for _, arg := range args {
+			id, err := parseItemID(arg)
+			if err != nil {
+				return err
+			}
+			actions = append(actions, typed(pocket.ActionFields{Action: action, ItemID: id, Time: now}))
+		}
+
+		return a.modify(ctx, actions)
+	}
+}
+
+func (a *app) tag(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError("no tag command")
+	}
+	switch args[0] {
+	case "stats":
+		return a.tagStats(ctx, args[1:])
+	case "clean":
+		return a.tagClean(ctx, args[1:])
+	}
+
+	f := pocket.ActionFields{Time: time.Now().Unix()}
+	switch sub, rest := args[0], args[1:]; sub {
+	case "add", "remove", "replace":
+		if len(rest) != 2 {
+			return usageError(fmt.Sprintf("tag %s takes an item id and tags", sub))
+		}
+		id, err := parseItemID(rest[0])
+		if err != nil {
+			return err
+		}
+		f.Action = map[string]pocket.ActionType{
+			"add":     pocket.ActionTagsAddType,
+			"remove":  pocket.ActionTagsRemoveType,
+			"replace": pocket.ActionTagsReplaceType,
+		}[sub]
+		f.ItemID = id
+		f.Tags = rest[1]
+	case "clear":
+		if len(rest) != 1 {
+			return usageError("tag clear takes an item id")
+		}
+		id, err := parseItemID(rest[0])
+		if err != nil {
+			return err
+		}
+		f.Action = pocket.ActionTagsClearType
+		f.ItemID = id
+	case "rename":
+		if len(rest) != 2 {
+			return usageError("tag rename takes the old and the new tag")
+		}
+		f.Action = pocket.ActionTagRenameType
+		f.OldTag = rest[0]
+		f.NewTag = rest[1]
+	case "delete":
+		if len(rest) != 1 {
+			return usageError("tag delete takes a tag")
+		}
+		f.Action = pocket.ActionTagDeleteType
+		f.Tag = rest[0]
+	default:
+		return usageError(fmt.Sprintf("unknown tag command %q", sub))
+	}
+
+	return a.modify(ctx, pocket.Actions{typed(f)})
+}
+
+// modify sends the actions and fails if Pocket rejected any of them.
+func (a *app) modify(ctx context.Context, actions pocket.Actions) error {
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	res, err := c.Modify(ctx, actions)
+	if err != nil {
+		return err
+	}
+
+	failed := rejected(actions, res)
+	for _, f := range failed {
+		fmt.Fprintf(a.stderr, "pocket: %s failed\n", f)
+	}
+	if len(failed) != 0 {
+		return fmt.Errorf("%d of %d actions failed", len(failed), len(actions))
+	}
+	return nil
+}
+
+// rejected describes the actions Pocket returned false for in action_results.
+func rejected(actions pocket.Actions, res *pocket.ModifyResponse) []string {
+	var failed []string
+	for i, r := range res.ActionResult {
+		if ok, isBool := r.(bool); isBool && !ok && i < len(actions) {
+			f, _ := pocket.ParseAction(actions[i])
+			failed = append(failed, fmt.Sprintf("%s of item %d", f.Action, f.ItemID))
+		}
+	}
+	return failed
+}
+
+// typed returns the typed action of fields built by the commands, their action types are always known.
+func typed(f pocket.ActionFields) interface{} {
+	a, err := f.Typed()
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func parseItemID(s string) (int64, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, usageError(fmt.Sprintf("invalid item id %q", s))
+	}
+	return id, nil
+}
+
+func itemFlags(item pocket.RetrieveListItem) string {
+	flags := ""
+	if item.IsFavorite() {
+		flags += "*"
+	}
+	if item.Status == pocket.ItemStatusArchived {
+		flags += "A"
+	}
+	if flags == "" {
+		return "-"
+	}
+	return flags
+}
+
+func itemTitle(item pocket.RetrieveListItem) string {
+	switch {
+	case item.ResolvedTitle != "":
+		return item.ResolvedTitle
+	case item.GivenTitle != "":
+		return item.GivenTitle
+	case item.ResolvedURL != "":
+		return item.ResolvedURL
+	}
+	return item.GivenURL
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02")
+}
+
+func formatTimeToRead(minutes int) string {
+	if minutes == 0 {
+		return "-"
+	}
+	return strconv.Itoa(minutes) + "m"
+}
+
+func truncate(s string, width int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > width {
+		return string(r[:width-1]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/pocketmock"
+	"github.com/stretchr/testify/require"
+)
+
+func listMock() *pocketmock.MockClient {
+	return &pocketmock.MockClient{
+		RetrieveFunc: func(ctx context.Context, rd *pocket.RetrieveInput) (*pocket.RetrieveResponse, error) {
+			return &pocket.RetrieveResponse{List: map[string]pocket.RetrieveListItem{
+				"1": {
+					SortID:        0,
+					ResolvedURL:   "https://go.dev/doc",
+					ResolvedTitle: "Documentation",
+					Favorite:      "1",
+					Status:        pocket.ItemStatusArchived,
+					TimeAdded:     "1600000000",
+					TimeToRead:    5,
+					WordCount:     "1200",
+				},
+				"2": {SortID: 1, GivenURL: "https://example.com", WordCount: "100"},
+			}}, nil
+		},
+	}
+}
+
+func TestApp_List(t *testing.T) {
+	ta := newTestApp(t, listMock())
+
+	require.Equal(t, 0, ta.run(context.Background(), []string{"list", "-state", "all", "-favorite", "1", "-tag", "go", "-count", "10"}))
+	require.Equal(t, `ID  FLAGS  ADDED       TTR  DOMAIN       TITLE
+1   *A     2020-09-13  5m   go.dev       Documentation
+2   -      -           -    example.com  https://example.com
+`, ta.stdout.String())
+
+	fav := pocket.Favorited
+	require.Equal(t, &pocket.RetrieveInput{State: pocket.All, Favorite: &fav, Tag: "go", Count: 10}, ta.mock.RetrieveCalls()[0].Rd)
+}
+
+func TestApp_ListQuery(t *testing.T) {
+	ta := newTestApp(t, listMock())
+
+	require.Equal(t, 0, ta.run(context.Background(), []string{"list", "-query", "domain:go.dev words>1000", "-sort", "oldest", "-json"}))
+
+	var items []pocket.RetrieveListItem
+	require.NoError(t, json.Unmarshal(ta.stdout.Bytes(), &items))
+	require.Len(t, items, 1)
+	require.Equal(t, "1", items[0].ItemID)
+
+	rd := ta.mock.RetrieveCalls()[0].Rd
+	require.Equal(t, "go.dev", rd.Domain)
+	require.Equal(t, pocket.Oldest, rd.Sort)
+}
+
+func TestApp_ListBadFavorite(t *testing.T) {
+	ta := newTestApp(t, listMock())
+	require.Equal(t, 2, ta.run(context.Background(), []string{"list", "-favorite", "yes"}))
+	require.Empty(t, ta.mock.RetrieveCalls())
+}
+
+func TestApp_Add(t *testing.T) {
+	ta := newTestApp(t, &pocketmock.MockClient{
+		AddFunc: func(ctx context.Context, ad *pocket.AddInput) (*pocket.AddResponse, error) {
+			return &pocket.AddResponse{Item: pocket.Item{ItemID: "42"}, Status: 1}, nil
+		},
+	})
+
+	require.Equal(t, 0, ta.run(context.Background(), []string{"add", "https://go.dev", "-tags", "go,lang"}))
+	require.Equal(t, "Added 42 https://go.dev\n", ta.stdout.String())
+	require.Equal(t, &pocket.AddInput{Url: "https://go.dev", Tags: "go,lang"}, ta.mock.AddCalls()[0].Ad)
+}
+
+func TestApp_Actions(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []pocket.ActionFields
+	}{
+		{
+			args: []string{"archive", "1", "2"},
+			want: []pocket.ActionFields{
+				{Action: pocket.ActionArchiveType, ItemID: 1},
+				{Action: pocket.ActionArchiveType, ItemID: 2},
+			},
+		},
+		{
+			args: []string{"unfav", "3"},
+			want: []pocket.ActionFields{{Action: pocket.ActionUnfavoriteType, ItemID: 3}},
+		},
+		{
+			args: []string{"tag", "replace", "4", "a,b"},
+			want: []pocket.ActionFields{{Action: pocket.ActionTagsReplaceType, ItemID: 4, Tags: "a,b"}},
+		},
+		{
+			args: []string{"tag", "clear", "5"},
+			want: []pocket.ActionFields{{Action: pocket.ActionTagsClearType, ItemID: 5}},
+		},
+		{
+			args: []string{"tag", "rename", "golang", "go"},
+			want: []pocket.ActionFields{{Action: pocket.ActionTagRenameType, OldTag: "golang", NewTag: "go"}},
+		},
+		{
+			args: []string{"tag", "delete", "old"},
+			want: []pocket.ActionFields{{Action: pocket.ActionTagDeleteType, Tag: "old"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.args[0], func(t *testing.T) {
+			ta := newTestApp(t, &pocketmock.MockClient{
+				ModifyFunc: func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+					return &pocket.ModifyResponse{Status: 1}, nil
+				},
+			})
+			require.Equal(t, 0, ta.run(context.Background(), test.args))
+
+			var got []pocket.ActionFields
+			for _, a := range ta.mock.ModifyCalls()[0].Actions {
+				f, err := pocket.ParseAction(a)
+				require.NoError(t, err)
+				require.NotZero(t, f.Time)
+				f.Time = 0
+				got = append(got, f)
+			}
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestApp_ActionFailed(t *testing.T) {
+	ta := newTestApp(t, &pocketmock.MockClient{
+		ModifyFunc: func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+			return &pocket.ModifyResponse{ActionResult: []interface{}{true, false}, Status: 1}, nil
+		},
+	})
+
+	require.Equal(t, 1, ta.run(context.Background(), []string{"delete", "1", "2"}))
+	require.Contains(t, ta.stderr.String(), "pocket: delete of item 2 failed")
+	require.Contains(t, ta.stderr.String(), "pocket: 1 of 2 actions failed")
+}
+
+func TestApp_TagUsage(t *testing.T) {
+	for _, args := range [][]string{
+		{"tag"},
+		{"tag", "add", "1"},
+		{"tag", "rename", "a"},
+		{"tag", "nope"},
+	} {
+		ta := newTestApp(t, &pocketmock.MockClient{})
+		require.Equal(t, 2, ta.run(context.Background(), args), args)
+	}
+}
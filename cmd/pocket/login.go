@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// manualRedirectURI is where Pocket sends the user after authorization in the manual mode.
+const manualRedirectURI = "https://getpocket.com/"
+
+// login authorizes the app. By default Pocket redirects the browser to a local server after
+// authorization, with -manual the user presses Enter instead.
+func (a *app) login(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("login", flag.ContinueOnError)
+	consumerKey := fs.String("consumer-key", "", "consumer key of the app, saved to the config")
+	addr := fs.String("addr", "127.0.0.1:0", "address of the local server Pocket redirects to")
+	manual := fs.Bool("manual", false, "press Enter after authorization instead of running a local server")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usageError("unexpected arguments")
+	}
+
+	cfg, err := loadConfig(a.configDir)
+	if err != nil {
+		return err
+	}
+	if *consumerKey != "" {
+		cfg.ConsumerKey = *consumerKey
+	}
+	key := a.consumerKey(cfg)
+	if key == "" {
+		return fmt.Errorf("consumer key is not set, set %s or pass -consumer-key", consumerKeyEnv)
+	}
+
+	var (
+		redirectURI = manualRedirectURI
+		wait        func(ctx context.Context) error
+	)
+	if *manual {
+		wait = a.waitEnter
+	} else {
+		l, err := net.Listen("tcp", *addr)
+		if err != nil {
+			return fmt.Errorf("error while starting local server: %w", err)
+		}
+		defer l.Close()
+
+		redirectURI = "http://" + l.Addr().String() + "/callback"
+		wait = func(ctx context.Context) error {
+			return waitCallback(ctx, l)
+		}
+	}
+
+	c := a.newClient(key)
+	if err := c.AuthApp(ctx, redirectURI); err != nil {
+		return fmt.Errorf("error while getting request token: %w", err)
+	}
+	link, err := c.MakeAuthUrl(redirectURI)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(a.stdout, "Open the link to authorize the app:")
+	fmt.Fprintln(a.stdout, link)
+	if err := wait(ctx); err != nil {
+		return err
+	}
+
+	res, err := c.GenerateAccessToken(ctx)
+	if err != nil {
+		return fmt.Errorf("error while getting access token: %w", err)
+	}
+
+	cfg.AccessToken = res.AccessToken
+	cfg.Username = res.Username
+	if err := saveConfig(a.configDir, cfg); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "Logged in as %s\n", res.Username)
+	return nil
+}
+
+func (a *app) waitEnter(ctx context.Context) error {
+	fmt.Fprintln(a.stdout, "Press Enter after authorization.")
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := bufio.NewReader(a.stdin).ReadString('\n')
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("error while reading input: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// waitCallback serves the listener until Pocket redirects the browser to /callback.
+func waitCallback(ctx context.Context, l net.Listener) error {
+	var once sync.Once
+	done := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "The app is authorized, you can close the page.")
+		once.Do(func() { close(done) })
+	})
+
+	srv := &http.Server{Handler: mux}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.Serve(l)
+	}()
+	defer srv.Close()
+
+	select {
+	case <-done:
+		return nil
+	case err := <-errs:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("error while serving callback: %w", err)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/pocketmock"
+	"github.com/stretchr/testify/require"
+)
+
+func loginMock() *pocketmock.MockClient {
+	return &pocketmock.MockClient{
+		AuthAppFunc: func(ctx context.Context, redirectURI string) error {
+			return nil
+		},
+		MakeAuthUrlFunc: func(redirectUri string, opts ...pocket.AuthUrlOption) (string, error) {
+			return "https://getpocket.com/auth/authorize?redirect_uri=" + redirectUri, nil
+		},
+		GenerateAccessTokenFunc: func(ctx context.Context) (*pocket.AuthUserResponse, error) {
+			return &pocket.AuthUserResponse{AccessToken: "new-token", Username: "gopher"}, nil
+		},
+	}
+}
+
+func TestApp_LoginManual(t *testing.T) {
+	ta := newTestApp(t, loginMock())
+	ta.getenv = func(string) string { return "" }
+	ta.stdin = strings.NewReader("\n")
+
+	require.Equal(t, 0, ta.run(context.Background(), []string{"login", "-manual", "-consumer-key", "flag-key"}))
+	require.Contains(t, ta.stdout.String(), "https://getpocket.com/auth/authorize?redirect_uri="+manualRedirectURI)
+	require.Contains(t, ta.stdout.String(), "Logged in as gopher")
+	require.Equal(t, []string{"flag-key"}, ta.keys)
+	require.Equal(t, manualRedirectURI, ta.mock.AuthAppCalls()[0].RedirectURI)
+
+	cfg, err := loadConfig(ta.configDir)
+	require.NoError(t, err)
+	require.Equal(t, &config{ConsumerKey: "flag-key", AccessToken: "new-token", Username: "gopher"}, cfg)
+}
+
+func TestApp_LoginCallback(t *testing.T) {
+	m := loginMock()
+	ta := newTestApp(t, m)
+
+	done := make(chan int)
+	go func() {
+		done <- ta.run(context.Background(), []string{"login"})
+	}()
+
+	var redirectURI string
+	require.Eventually(t, func() bool {
+		calls := m.MakeAuthUrlCalls()
+		if len(calls) == 0 {
+			return false
+		}
+		redirectURI = calls[0].RedirectUri
+		return true
+	}, time.Second, 10*time.Millisecond)
+	require.True(t, strings.HasPrefix(redirectURI, "http://127.0.0.1:"))
+
+	resp, err := http.Get(redirectURI)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.Equal(t, 0, <-done)
+	cfg, err := loadConfig(ta.configDir)
+	require.NoError(t, err)
+	require.Equal(t, "new-token", cfg.AccessToken)
+}
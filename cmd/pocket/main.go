@@ -0,0 +1,188 @@
+// Command pocket manages a Pocket list from the terminal.
+//
+// Usage:
+//
+//	pocket login
+//	pocket add <url> [-tags a,b] [-title title]
+//	pocket list [filters] [-json]
+//	pocket archive|readd|fav|unfav|delete <item id>...
+//	pocket tag add|remove|replace <item id> <tags>
+//	pocket tag clear <item id>
+//	pocket tag rename <old> <new>
+//	pocket tag delete <tag>
+//	pocket export [-format netscape|pocket|csv|markdown|jsonl|opml] [-o path]
+//	pocket import [-format html|pocket|csv|jsonl|opml] <file>
+//	pocket tui [-state s] [-tag t] [-domain d] [-search s] [-sort s]
+//
+// The consumer key is read from the POCKET_CONSUMER_KEY environment variable or
+// the config file, the access token is saved to the config file by login.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+const consumerKeyEnv = "POCKET_CONSUMER_KEY"
+
+// usageError is returned by commands called with wrong arguments, the usage of the command is printed after it.
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+type command struct {
+	usage string
+	run   func(a *app, ctx context.Context, args []string) error
+}
+
+var commands = map[string]command{
+	"login":   {"login [-consumer-key key] [-addr host:port] [-manual]", (*app).login},
+	"add":     {"add <url> [-tags a,b] [-title title]", (*app).add},
+	"list":    {"list [-state s] [-favorite 0|1] [-tag t] [-type t] [-sort s] [-detail d] [-search s] [-domain d] [-since unix] [-count n] [-offset n] [-query q] [-json]", (*app).list},
+	"archive": {"archive <item id>...", itemAction(pocket.ActionArchiveType)},
+	"readd":   {"readd <item id>...", itemAction(pocket.ActionReaddType)},
+	"fav":     {"fav <item id>...", itemAction(pocket.ActionFavoriteType)},
+	"unfav":   {"unfav <item id>...", itemAction(pocket.ActionUnfavoriteType)},
+	"delete":  {"delete <item id>...", itemAction(pocket.ActionDeleteType)},
+	"tag":     {"tag add|remove|replace <item id> <tags> | clear <item id> | rename <old> <new> | delete <tag> | stats | clean [-apply] [-distance n] [-min n]", (*app).tag},
+	"export":  {"export [-format netscape|pocket|csv|markdown|jsonl|opml] [-o path] [-state s]", (*app).export},
+	"import":  {"import [-format html|pocket|csv|jsonl|opml] [-progress path] <file>", (*app).importFile},
+	"dedupe":  {"dedupe [-apply] [-titles similarity]", (*app).dedupe},
+	"tui":     {"tui [-state s] [-tag t] [-domain d] [-search s] [-sort s]", (*app).tui},
+}
+
+// app holds what commands need, so tests can replace the client and the output.
+type app struct {
+	stdin     io.Reader
+	stdout    io.Writer
+	stderr    io.Writer
+	configDir string
+	getenv    func(key string) string
+	newClient func(consumerKey string) pocket.Client
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	dir, err := defaultConfigDir()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "pocket:", err)
+		os.Exit(1)
+	}
+
+	a := &app{
+		stdin:     os.Stdin,
+		stdout:    os.Stdout,
+		stderr:    os.Stderr,
+		configDir: dir,
+		getenv:    os.Getenv,
+		newClient: func(consumerKey string) pocket.Client {
+			return pocket.New(consumerKey).WithRetry(3, time.Second)
+		},
+	}
+	os.Exit(a.run(ctx, os.Args[1:]))
+}
+
+func (a *app) run(ctx context.Context, args []string) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		a.usage()
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(a.stderr, "pocket: unknown command %q\n", args[0])
+		a.usage()
+		return 2
+	}
+
+	err := cmd.run(a, ctx, args[1:])
+	var ue usageError
+	switch {
+	case errors.As(err, &ue):
+		if ue != "" {
+			fmt.Fprintln(a.stderr, "pocket:", ue)
+		}
+		fmt.Fprintln(a.stderr, "usage: pocket", cmd.usage)
+		return 2
+	case err != nil:
+		fmt.Fprintln(a.stderr, "pocket:", err)
+		return 1
+	}
+	return 0
+}
+
+func (a *app) usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(a.stderr, "usage:")
+	for _, name := range names {
+		fmt.Fprintln(a.stderr, "  pocket", commands[name].usage)
+	}
+}
+
+// parseFlags parses flags placed before, between and after positional arguments and returns the positional ones.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	fs.SetOutput(io.Discard)
+
+	var positional []string
+	for {
+		err := fs.Parse(args)
+		switch {
+		case errors.Is(err, flag.ErrHelp):
+			return nil, usageError("")
+		case err != nil:
+			return nil, usageError(err.Error())
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// client returns a client authorized with the saved access token.
+func (a *app) client() (pocket.Client, error) {
+	cfg, err := loadConfig(a.configDir)
+	if err != nil {
+		return nil, err
+	}
+
+	key := a.consumerKey(cfg)
+	if key == "" {
+		return nil, fmt.Errorf("consumer key is not set, set %s or run pocket login -consumer-key", consumerKeyEnv)
+	}
+	if cfg.AccessToken == "" {
+		return nil, errors.New("not logged in, run pocket login")
+	}
+
+	c := a.newClient(key)
+	c.SetAccessToken(cfg.AccessToken)
+	return c, nil
+}
+
+func (a *app) consumerKey(cfg *config) string {
+	if key := strings.TrimSpace(a.getenv(consumerKeyEnv)); key != "" {
+		return key
+	}
+	return cfg.ConsumerKey
+}
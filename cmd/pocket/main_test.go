@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"strings"
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/pocketmock"
+	"github.com/stretchr/testify/require"
+)
+
+type testApp struct {
+	*app
+	mock   *pocketmock.MockClient
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+	keys   []string
+}
+
+// newTestApp returns an app logged in with the mock client.
+func newTestApp(t *testing.T, m *pocketmock.MockClient) *testApp {
+	ta := &testApp{mock: m, stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}}
+	ta.app = &app{
+		stdin:     strings.NewReader(""),
+		stdout:    ta.stdout,
+		stderr:    ta.stderr,
+		configDir: t.TempDir(),
+		getenv: func(key string) string {
+			if key == consumerKeyEnv {
+				return "consumer-key"
+			}
+			return ""
+		},
+		newClient: func(consumerKey string) pocket.Client {
+			ta.keys = append(ta.keys, consumerKey)
+			return m
+		},
+	}
+	require.NoError(t, saveConfig(ta.configDir, &config{AccessToken: "access-token"}))
+	return ta
+}
+
+func TestApp_Run(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stderr string
+	}{
+		{"no command", nil, 2, "usage:\n"},
+		{"unknown command", []string{"nope"}, 2, `pocket: unknown command "nope"`},
+		{"bad flag", []string{"list", "-nope"}, 2, "pocket: flag provided but not defined: -nope\nusage: pocket list"},
+		{"help", []string{"add", "-h"}, 2, "usage: pocket add <url>"},
+		{"bad args", []string{"archive", "x"}, 2, `pocket: invalid item id "x"`},
+		{"api error", []string{"fav", "1"}, 1, "pocket: " + pocketmock.ErrNotProgrammed.Error()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ta := newTestApp(t, &pocketmock.MockClient{})
+			require.Equal(t, test.code, ta.run(context.Background(), test.args))
+			require.Contains(t, ta.stderr.String(), test.stderr)
+		})
+	}
+}
+
+func TestApp_Client(t *testing.T) {
+	ta := newTestApp(t, &pocketmock.MockClient{})
+
+	c, err := ta.client()
+	require.NoError(t, err)
+	require.Equal(t, "access-token", c.GetAccessToken())
+	require.Equal(t, []string{"consumer-key"}, ta.keys)
+
+	ta.getenv = func(string) string { return "" }
+	_, err = ta.client()
+	require.Error(t, err)
+
+	require.NoError(t, saveConfig(ta.configDir, &config{ConsumerKey: "saved-key"}))
+	_, err = ta.client()
+	require.EqualError(t, err, "not logged in, run pocket login")
+}
+
+func TestParseFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	tags := fs.String("tags", "", "")
+	json := fs.Bool("json", false, "")
+
+	positional, err := parseFlags(fs, []string{"a", "-tags", "x,y", "b", "-json", "c"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c"}, positional)
+	require.Equal(t, "x,y", *tags)
+	require.True(t, *json)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/tags"
+)
+
+func (a *app) tagStats(ctx context.Context, args []string) error {
+	if len(args) != 0 {
+		return usageError("tag stats takes no arguments")
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	usage, err := tagUsage(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ITEMS\tTAG")
+	for _, u := range usage {
+		fmt.Fprintf(tw, "%d\t%s\n", u.Count, u.Tag)
+	}
+	return tw.Flush()
+}
+
+// tagClean prints proposed tag merges and deletions, and sends them with -apply.
+func (a *app) tagClean(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("tag clean", flag.ContinueOnError)
+	apply := fs.Bool("apply", false, "send the changes, only print them if false")
+	distance := fs.Int("distance", 0, "max edit distance of misspelled tags, misspellings are not looked for if 0")
+	minCount := fs.Int("min", 0, "delete tags used by fewer items")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usageError("unexpected arguments")
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	usage, err := tagUsage(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	proposals := tags.Propose(usage, tags.Options{MaxDistance: *distance, MinCount: *minCount})
+	r, err := tags.Apply(ctx, c, proposals, !*apply)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(a.stdout, r.String())
+	return err
+}
+
+func tagUsage(ctx context.Context, c pocket.Client) ([]tags.Usage, error) {
+	items, err := pocket.RetrieveAll(ctx, c, &pocket.RetrieveInput{
+		State:      pocket.All,
+		DetailType: pocket.Complete,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tags.Count(items), nil
+}
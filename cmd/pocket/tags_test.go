@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/pocketmock"
+	"github.com/stretchr/testify/require"
+)
+
+func tagsMock() *pocketmock.MockClient {
+	tagged := func(sortID int, tags ...string) pocket.RetrieveListItem {
+		item := pocket.RetrieveListItem{SortID: sortID, Tags: map[string]pocket.Tag{}}
+		for _, tag := range tags {
+			item.Tags[tag] = pocket.Tag{Tag: tag}
+		}
+		return item
+	}
+	return &pocketmock.MockClient{
+		RetrieveFunc: func(ctx context.Context, rd *pocket.RetrieveInput) (*pocket.RetrieveResponse, error) {
+			return &pocket.RetrieveResponse{List: map[string]pocket.RetrieveListItem{
+				"1": tagged(0, "go", "news"),
+				"2": tagged(1, "go"),
+				"3": tagged(2, "Go"),
+			}}, nil
+		},
+		ModifyFunc: func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+			return &pocket.ModifyResponse{Status: 1}, nil
+		},
+	}
+}
+
+func TestApp_TagStats(t *testing.T) {
+	ta := newTestApp(t, tagsMock())
+
+	require.Equal(t, 0, ta.run(context.Background(), []string{"tag", "stats"}))
+	require.Equal(t, `ITEMS  TAG
+2      go
+1      Go
+1      news
+`, ta.stdout.String())
+	require.Equal(t, pocket.Complete, ta.mock.RetrieveCalls()[0].Rd.DetailType)
+}
+
+func TestApp_TagClean(t *testing.T) {
+	ta := newTestApp(t, tagsMock())
+
+	require.Equal(t, 0, ta.run(context.Background(), []string{"tag", "clean", "-min", "2"}))
+	require.Equal(t, `merge "Go" into "go" (case, 1 items)
+delete "news" (rare, 1 items)
+dry run, 2 actions not sent
+`, ta.stdout.String())
+	require.Empty(t, ta.mock.ModifyCalls())
+
+	ta = newTestApp(t, tagsMock())
+	require.Equal(t, 0, ta.run(context.Background(), []string{"tag", "clean", "-apply"}))
+	require.Len(t, ta.mock.ModifyCalls(), 1)
+	require.Equal(t, pocket.Actions{&pocket.ActionTagRename{
+		Action: pocket.ActionTagRenameType,
+		OldTag: "Go",
+		NewTag: "go",
+		Time:   ta.mock.ModifyCalls()[0].Actions[0].(*pocket.ActionTagRename).Time,
+	}}, ta.mock.ModifyCalls()[0].Actions)
+}
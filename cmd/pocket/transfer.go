@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/export"
+	"github.com/VladimirStepanov/pocket-golang-sdk/importer"
+)
+
+const defaultNotesDir = "pocket-notes"
+
+func (a *app) export(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "netscape", "netscape, pocket, csv, markdown, jsonl or opml")
+	out := fs.String("o", "", "output file, the directory for markdown; stdout if empty")
+	state := fs.String("state", string(pocket.All), "unread, archive or all")
+	timeFormat := fs.String("time-format", "", "time layout of csv, unix seconds if empty")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usageError("unexpected arguments")
+	}
+
+	var write func(w io.Writer, items []pocket.RetrieveListItem) error
+	switch *format {
+	case "netscape", "html":
+		write = export.Netscape
+	case "pocket":
+		write = export.PocketHTML
+	case "csv":
+		write = func(w io.Writer, items []pocket.RetrieveListItem) error {
+			return export.CSV(w, items, export.CSVOptions{TimeFormat: *timeFormat})
+		}
+	case "jsonl":
+		write = export.JSONLines
+	case "opml":
+		write = func(w io.Writer, items []pocket.RetrieveListItem) error {
+			return export.OPML(w, items, "Pocket")
+		}
+	case "markdown", "md":
+	default:
+		return usageError(fmt.Sprintf("unknown format %q", *format))
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	items, err := pocket.RetrieveAll(ctx, c, &pocket.RetrieveInput{
+		State:      pocket.State(*state),
+		DetailType: pocket.Complete,
+	})
+	if err != nil {
+		return err
+	}
+
+	if write == nil {
+		dir := *out
+		if dir == "" {
+			dir = defaultNotesDir
+		}
+		if err := export.MarkdownDir(dir, items); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stderr, "Exported %d items to %s\n", len(items), dir)
+		return nil
+	}
+
+	if *out == "" {
+		return write(a.stdout, items)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return fmt.Errorf("error while creating output file: %w", err)
+	}
+	if err := write(f, items); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("error while closing output file: %w", err)
+	}
+	fmt.Fprintf(a.stderr, "Exported %d items to %s\n", len(items), *out)
+	return nil
+}
+
+func (a *app) importFile(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := fs.String("format", "", "html, pocket, csv, jsonl or opml; found by the file extension if empty")
+	progress := fs.String("progress", "", "progress file to continue a failed import, in the config directory by default")
+	timeFormat := fs.String("time-format", "", "time layout of csv, unix seconds if empty")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageError("import takes one file")
+	}
+	path := positional[0]
+
+	if *format == "" {
+		*format = formatOf(path)
+	}
+
+	var parse func(r io.Reader) ([]importer.Bookmark, error)
+	switch *format {
+	case "html", "netscape", "pocket":
+		parse = importer.ParseHTML
+	case "csv":
+		parse = func(r io.Reader) ([]importer.Bookmark, error) {
+			return importer.ParseCSV(r, importer.CSVOptions{TimeFormat: *timeFormat})
+		}
+	case "jsonl":
+		parse = func(r io.Reader) ([]importer.Bookmark, error) {
+			items, err := importer.ReadJSONLines(r)
+			return importer.FromItems(items), err
+		}
+	case "opml":
+		parse = importer.ParseOPML
+	default:
+		return usageError(fmt.Sprintf("unknown format %q, set -format", *format))
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error while opening import file: %w", err)
+	}
+	defer f.Close()
+
+	bookmarks, err := parse(f)
+	if err != nil {
+		return err
+	}
+
+	if *progress == "" {
+		*progress = filepath.Join(a.configDir, "import-"+filepath.Base(path)+".progress")
+		if err := os.MkdirAll(a.configDir, 0o700); err != nil {
+			return fmt.Errorf("error while creating config directory: %w", err)
+		}
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	res, err := importer.New(ctx, c).
+		WithProgressFile(*progress).
+		WithProgress(func(done, total int) {
+			fmt.Fprintf(a.stderr, "\rImported %d of %d", done, total)
+		}).
+		Import(bookmarks)
+	if res != nil && res.Done != 0 {
+		fmt.Fprintln(a.stderr)
+	}
+	if err != nil {
+		return fmt.Errorf("%w; run the import again to continue", err)
+	}
+
+	fmt.Fprintf(a.stdout, "Imported %d items, skipped %d already saved", res.Done, res.Duplicates)
+	if res.Resumed != 0 {
+		fmt.Fprintf(a.stdout, " and %d imported before", res.Resumed)
+	}
+	fmt.Fprintln(a.stdout)
+	return nil
+}
+
+func formatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		return "html"
+	case ".csv":
+		return "csv"
+	case ".jsonl", ".json":
+		return "jsonl"
+	case ".opml", ".xml":
+		return "opml"
+	}
+	return ""
+}
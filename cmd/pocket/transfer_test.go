@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/pocketmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_Export(t *testing.T) {
+	ta := newTestApp(t, listMock())
+
+	require.Equal(t, 0, ta.run(context.Background(), []string{"export", "-format", "csv"}))
+	require.Equal(t, `url,title,tags,status,favorite,time_added,word_count,domain
+https://go.dev/doc,Documentation,,archived,true,1600000000,1200,go.dev
+https://example.com,,,unread,false,,100,example.com
+`, ta.stdout.String())
+
+	rd := ta.mock.RetrieveCalls()[0].Rd
+	require.Equal(t, pocket.All, rd.State)
+	require.Equal(t, pocket.Complete, rd.DetailType)
+
+	dir := filepath.Join(t.TempDir(), "notes")
+	require.Equal(t, 0, ta.run(context.Background(), []string{"export", "-format", "markdown", "-o", dir}))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	ta.stdout.Reset()
+	require.Equal(t, 0, ta.run(context.Background(), []string{"export", "-format", "pocket"}))
+	require.Contains(t, ta.stdout.String(), `<h1>Read Archive</h1>`)
+
+	require.Equal(t, 2, ta.run(context.Background(), []string{"export", "-format", "pdf"}))
+}
+
+func TestApp_Import(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "links.csv")
+	require.NoError(t, os.WriteFile(path, []byte("url,tags\nhttps://go.dev,go\nhttps://example.com,\n"), 0o644))
+
+	fail := true
+	ta := newTestApp(t, &pocketmock.MockClient{
+		RetrieveFunc: func(ctx context.Context, rd *pocket.RetrieveInput) (*pocket.RetrieveResponse, error) {
+			return &pocket.RetrieveResponse{List: map[string]pocket.RetrieveListItem{
+				"1": {GivenURL: "https://example.com/"},
+			}}, nil
+		},
+		ModifyFunc: func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+			if fail {
+				return nil, errors.New("network is down")
+			}
+			return &pocket.ModifyResponse{Status: 1}, nil
+		},
+	})
+
+	require.Equal(t, 1, ta.run(context.Background(), []string{"import", path}))
+	require.Contains(t, ta.stderr.String(), "network is down; run the import again to continue")
+
+	fail = false
+	require.Equal(t, 0, ta.run(context.Background(), []string{"import", path}))
+	require.Equal(t, "Imported 1 items, skipped 1 already saved\n", ta.stdout.String())
+
+	f, err := pocket.ParseAction(ta.mock.ModifyCalls()[1].Actions[0])
+	require.NoError(t, err)
+	require.Equal(t, "https://go.dev", f.Url)
+	require.Equal(t, "go", f.Tags)
+
+	require.Equal(t, 2, ta.run(context.Background(), []string{"import", "links.txt"}))
+}
@@ -0,0 +1,460 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+const (
+	defaultPageSize = 20
+	// lines of the screen taken by the header and the status line
+	screenChrome = 4
+)
+
+// ANSI escape sequences
+const (
+	altScreenOn  = "\x1b[?1049h"
+	altScreenOff = "\x1b[?1049l"
+	hideCursor   = "\x1b[?25l"
+	showCursor   = "\x1b[?25h"
+	clearScreen  = "\x1b[H\x1b[2J"
+	reverse      = "\x1b[7m"
+	dim          = "\x1b[2m"
+	reset        = "\x1b[0m"
+)
+
+const tuiHelp = "j/k move  n/p page  a archive  f favorite  t tag  o open  s save  q save and quit  Q quit"
+
+// tui runs the reading list browser on the terminal. Actions are queued and sent
+// with one Modify call on save or quit.
+func (a *app) tui(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("tui", flag.ContinueOnError)
+	state := fs.String("state", string(pocket.Unread), "unread, archive or all")
+	tag := fs.String("tag", "", "tag name or _untagged_")
+	domain := fs.String("domain", "", "items of the domain")
+	search := fs.String("search", "", "search in titles and urls")
+	sort := fs.String("sort", string(pocket.Newest), "newest, oldest, title or site")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usageError("unexpected arguments")
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return fmt.Errorf("error while opening terminal: %w", err)
+	}
+	defer tty.Close()
+
+	pageSize := defaultPageSize
+	if rows, err := terminalRows(tty); err == nil && rows > screenChrome+1 {
+		pageSize = rows - screenChrome
+	}
+
+	restore, err := makeRaw(tty)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	io.WriteString(tty, altScreenOn+hideCursor)
+	defer io.WriteString(tty, showCursor+altScreenOff)
+
+	b := newBrowser(c, pocket.RetrieveInput{
+		State:  pocket.State(*state),
+		Tag:    *tag,
+		Domain: *domain,
+		Search: *search,
+		Sort:   pocket.Sort(*sort),
+	}, pageSize)
+	return b.run(ctx, tty, tty)
+}
+
+// browser is the state of the tui, separate from the terminal so it can be driven by tests.
+type browser struct {
+	client   pocket.Client
+	input    pocket.RetrieveInput
+	pageSize int
+	open     func(url string) error
+
+	page    int
+	items   []pocket.RetrieveListItem
+	cursor  int
+	pending pocket.Actions
+	message string
+
+	prompt    bool // the tag prompt is shown
+	promptBuf []rune
+}
+
+func newBrowser(c pocket.Client, in pocket.RetrieveInput, pageSize int) *browser {
+	return &browser{
+		client:   c,
+		input:    in,
+		pageSize: pageSize,
+		open:     openURL,
+	}
+}
+
+func (b *browser) run(ctx context.Context, r io.Reader, w io.Writer) error {
+	if err := b.load(ctx); err != nil {
+		return err
+	}
+
+	br := bufio.NewReader(r)
+	for {
+		b.render(w)
+
+		key, err := readKey(br)
+		if errors.Is(err, io.EOF) {
+			return b.flush(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("error while reading key: %w", err)
+		}
+
+		if quit := b.handle(ctx, key); quit {
+			return nil
+		}
+	}
+}
+
+// load retrieves the current page.
+func (b *browser) load(ctx context.Context) error {
+	in := b.input
+	in.Count = int64(b.pageSize)
+	in.Offset = int64(b.page * b.pageSize)
+
+	items, err := pocket.RetrieveAll(ctx, b.client, &in)
+	if err != nil {
+		return err
+	}
+	b.items = items
+	b.cursor = 0
+	return nil
+}
+
+// handle applies the key and reports whether the browser should quit.
+func (b *browser) handle(ctx context.Context, key string) bool {
+	if b.prompt {
+		b.handlePrompt(key)
+		return false
+	}
+
+	b.message = ""
+	switch key {
+	case "j", "down":
+		if b.cursor < len(b.items)-1 {
+			b.cursor++
+		}
+	case "k", "up":
+		if b.cursor > 0 {
+			b.cursor--
+		}
+	case "n", "right", " ":
+		if len(b.items) < b.pageSize {
+			b.message = "last page"
+			return false
+		}
+		b.turn(ctx, 1)
+	case "p", "left":
+		if b.page == 0 {
+			b.message = "first page"
+			return false
+		}
+		b.turn(ctx, -1)
+	case "a":
+		b.toggleArchive()
+	case "f":
+		b.toggleFavorite()
+	case "t":
+		if _, ok := b.current(); ok {
+			b.prompt = true
+			b.promptBuf = nil
+		}
+	case "o":
+		if item, ok := b.current(); ok {
+			if err := b.open(browserURL(item)); err != nil {
+				b.message = "error while opening: " + err.Error()
+			}
+		}
+	case "s":
+		if err := b.flush(ctx); err != nil {
+			b.message = err.Error()
+		}
+	case "q", "ctrl-c":
+		if err := b.flush(ctx); err != nil {
+			b.message = err.Error() + "; Q quits without saving"
+			return false
+		}
+		return true
+	case "Q":
+		return true
+	}
+	return false
+}
+
+func (b *browser) handlePrompt(key string) {
+	switch key {
+	case "enter":
+		b.prompt = false
+		tags := strings.TrimSpace(string(b.promptBuf))
+		if tags != "" {
+			b.addTags(tags)
+		}
+	case "esc", "ctrl-c":
+		b.prompt = false
+	case "backspace":
+		if len(b.promptBuf) != 0 {
+			b.promptBuf = b.promptBuf[:len(b.promptBuf)-1]
+		}
+	default:
+		if r := []rune(key); len(r) == 1 {
+			b.promptBuf = append(b.promptBuf, r[0])
+		}
+	}
+}
+
+// turn loads the next or the previous page. Queued actions are kept.
+func (b *browser) turn(ctx context.Context, delta int) {
+	b.page += delta
+	if err := b.load(ctx); err != nil {
+		b.page -= delta
+		b.message = err.Error()
+	}
+}
+
+func (b *browser) current() (*pocket.RetrieveListItem, bool) {
+	if b.cursor >= len(b.items) {
+		return nil, false
+	}
+	return &b.items[b.cursor], true
+}
+
+func (b *browser) toggleArchive() {
+	item, ok := b.current()
+	if !ok {
+		return
+	}
+	if item.Status == pocket.ItemStatusArchived {
+		item.Status = pocket.ItemStatusUnread
+		b.queue(item, pocket.ActionFields{Action: pocket.ActionReaddType})
+	} else {
+		item.Status = pocket.ItemStatusArchived
+		b.queue(item, pocket.ActionFields{Action: pocket.ActionArchiveType})
+	}
+}
+
+func (b *browser) toggleFavorite() {
+	item, ok := b.current()
+	if !ok {
+		return
+	}
+	if item.IsFavorite() {
+		item.Favorite = "0"
+		b.queue(item, pocket.ActionFields{Action: pocket.ActionUnfavoriteType})
+	} else {
+		item.Favorite = "1"
+		b.queue(item, pocket.ActionFields{Action: pocket.ActionFavoriteType})
+	}
+}
+
+func (b *browser) addTags(tags string) {
+	item, ok := b.current()
+	if !ok {
+		return
+	}
+	if item.Tags == nil {
+		item.Tags = map[string]pocket.Tag{}
+	}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			item.Tags[tag] = pocket.Tag{ItemID: item.ItemID, Tag: tag}
+		}
+	}
+	b.queue(item, pocket.ActionFields{Action: pocket.ActionTagsAddType, Tags: tags})
+}
+
+// queue adds the action on the item, the item itself is already changed to show the result.
+func (b *browser) queue(item *pocket.RetrieveListItem, f pocket.ActionFields) {
+	id, err := strconv.ParseInt(item.ItemID, 10, 64)
+	if err != nil {
+		b.message = "invalid item id " + item.ItemID
+		return
+	}
+	f.ItemID = id
+	f.Time = time.Now().Unix()
+	b.pending = append(b.pending, typed(f))
+}
+
+// flush sends queued actions with one Modify call. Actions rejected by Pocket are reported
+// and dropped, sending them again wouldn't help.
+func (b *browser) flush(ctx context.Context) error {
+	if len(b.pending) == 0 {
+		return nil
+	}
+
+	actions := b.pending
+	res, err := b.client.Modify(ctx, actions)
+	if err != nil {
+		return fmt.Errorf("error while saving %d actions: %w", len(actions), err)
+	}
+	b.pending = nil
+
+	if failed := rejected(actions, res); len(failed) != 0 {
+		return fmt.Errorf("%d of %d actions failed: %s", len(failed), len(actions), strings.Join(failed, ", "))
+	}
+	b.message = fmt.Sprintf("saved %d actions", len(actions))
+	return nil
+}
+
+func (b *browser) render(w io.Writer) {
+	sb := strings.Builder{}
+	sb.WriteString(clearScreen)
+	fmt.Fprintf(&sb, "Pocket, page %d, %d queued actions\r\n\r\n", b.page+1, len(b.pending))
+
+	if len(b.items) == 0 {
+		sb.WriteString("No items\r\n")
+	}
+	for i, item := range b.items {
+		line := fmt.Sprintf("%-2s %-60s %4s  %s",
+			itemFlags(item),
+			truncate(itemTitle(item), maxTitleWidth),
+			formatTimeToRead(item.TimeToRead),
+			item.Domain(),
+		)
+		if tags := item.TagNames(); len(tags) != 0 {
+			line += dim + "  #" + strings.Join(tags, " #") + reset
+		}
+		if i == b.cursor {
+			line = reverse + line + reset
+		}
+		sb.WriteString(line + "\r\n")
+	}
+
+	sb.WriteString("\r\n")
+	switch {
+	case b.prompt:
+		sb.WriteString("tags to add: " + string(b.promptBuf))
+	case b.message != "":
+		sb.WriteString(b.message)
+	default:
+		sb.WriteString(dim + tuiHelp + reset)
+	}
+
+	io.WriteString(w, sb.String())
+}
+
+// readKey reads one keystroke of a terminal in raw mode.
+func readKey(r *bufio.Reader) (string, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return "", err
+	}
+
+	switch c {
+	case '\r', '\n':
+		return "enter", nil
+	case 3:
+		return "ctrl-c", nil
+	case 127, 8:
+		return "backspace", nil
+	case 27:
+		if r.Buffered() == 0 {
+			return "esc", nil
+		}
+		next, _, err := r.ReadRune()
+		if err != nil || next != '[' {
+			return "esc", nil
+		}
+		code, _, err := r.ReadRune()
+		if err != nil {
+			return "esc", nil
+		}
+		switch code {
+		case 'A':
+			return "up", nil
+		case 'B':
+			return "down", nil
+		case 'C':
+			return "right", nil
+		case 'D':
+			return "left", nil
+		}
+		return "esc", nil
+	}
+	return string(c), nil
+}
+
+func browserURL(item *pocket.RetrieveListItem) string {
+	if item.ResolvedURL != "" {
+		return item.ResolvedURL
+	}
+	return item.GivenURL
+}
+
+// openURL opens the url in the default browser.
+func openURL(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+// makeRaw switches the terminal to raw mode with stty and returns the func restoring the previous mode.
+func makeRaw(tty *os.File) (func(), error) {
+	state, err := stty(tty, "-g")
+	if err != nil {
+		return nil, fmt.Errorf("error while saving terminal state: %w", err)
+	}
+	if _, err := stty(tty, "raw", "-echo"); err != nil {
+		return nil, fmt.Errorf("error while switching terminal to raw mode: %w", err)
+	}
+	return func() {
+		stty(tty, strings.TrimSpace(state))
+	}, nil
+}
+
+func terminalRows(tty *os.File) (int, error) {
+	size, err := stty(tty, "size")
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(size)
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("unexpected stty size output %q", size)
+	}
+	return strconv.Atoi(fields[0])
+}
+
+func stty(tty *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = tty
+	out, err := cmd.Output()
+	return string(out), err
+}
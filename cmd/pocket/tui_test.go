@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/pocketmock"
+	"github.com/stretchr/testify/require"
+)
+
+func tuiMock(total int) *pocketmock.MockClient {
+	return &pocketmock.MockClient{
+		RetrieveFunc: func(ctx context.Context, rd *pocket.RetrieveInput) (*pocket.RetrieveResponse, error) {
+			list := map[string]pocket.RetrieveListItem{}
+			for i := rd.Offset; i < rd.Offset+rd.Count && i < int64(total); i++ {
+				id := strconv.FormatInt(i+1, 10)
+				list[id] = pocket.RetrieveListItem{
+					SortID:        int(i),
+					GivenURL:      "https://example.com/" + id,
+					ResolvedTitle: "Item " + id,
+				}
+			}
+			return &pocket.RetrieveResponse{List: list}, nil
+		},
+		ModifyFunc: func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+			return &pocket.ModifyResponse{Status: 1}, nil
+		},
+	}
+}
+
+func actionsOf(t *testing.T, actions pocket.Actions) []pocket.ActionFields {
+	var res []pocket.ActionFields
+	for _, a := range actions {
+		f, err := pocket.ParseAction(a)
+		require.NoError(t, err)
+		f.Time = 0
+		res = append(res, f)
+	}
+	return res
+}
+
+func TestBrowser_Run(t *testing.T) {
+	m := tuiMock(5)
+	b := newBrowser(m, pocket.RetrieveInput{State: pocket.Unread}, 3)
+	var opened []string
+	b.open = func(url string) error {
+		opened = append(opened, url)
+		return nil
+	}
+
+	// archive the first item, favorite the second one and tag it, go to the next page,
+	// open its first item, favorite it twice and quit
+	keys := "aj\x1b[Bkff" + "tgo,x\x7f\x7fread\r" + "n" + "o" + "ff" + "q"
+	out := bytes.Buffer{}
+	require.NoError(t, b.run(context.Background(), strings.NewReader(keys), &out))
+
+	require.Equal(t, []string{"https://example.com/4"}, opened)
+	require.Equal(t, 1, b.page)
+
+	calls := m.ModifyCalls()
+	require.Len(t, calls, 1)
+	require.Equal(t, []pocket.ActionFields{
+		{Action: pocket.ActionArchiveType, ItemID: 1},
+		{Action: pocket.ActionFavoriteType, ItemID: 2},
+		{Action: pocket.ActionUnfavoriteType, ItemID: 2},
+		{Action: pocket.ActionTagsAddType, ItemID: 2, Tags: "goread"},
+		{Action: pocket.ActionFavoriteType, ItemID: 4},
+		{Action: pocket.ActionUnfavoriteType, ItemID: 4},
+	}, actionsOf(t, calls[0].Actions))
+
+	rds := m.RetrieveCalls()
+	require.Len(t, rds, 2)
+	require.Equal(t, int64(3), rds[1].Rd.Offset)
+	require.Equal(t, pocket.Unread, rds[1].Rd.State)
+
+	require.Contains(t, out.String(), reverse+"-  Item 4")
+	require.Contains(t, out.String(), "tags to add: go,x")
+}
+
+func TestBrowser_Paging(t *testing.T) {
+	b := newBrowser(tuiMock(3), pocket.RetrieveInput{}, 3)
+	require.NoError(t, b.load(context.Background()))
+
+	b.handle(context.Background(), "p")
+	require.Equal(t, "first page", b.message)
+
+	b.handle(context.Background(), "n")
+	require.Equal(t, 1, b.page)
+	require.Empty(t, b.items)
+
+	b.handle(context.Background(), "n")
+	require.Equal(t, "last page", b.message)
+	require.Equal(t, 1, b.page)
+}
+
+func TestBrowser_FlushFailed(t *testing.T) {
+	m := tuiMock(1)
+	m.ModifyFunc = func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+		return nil, errors.New("network is down")
+	}
+
+	b := newBrowser(m, pocket.RetrieveInput{}, 3)
+	require.NoError(t, b.run(context.Background(), strings.NewReader("aqQ"), &bytes.Buffer{}))
+	require.Len(t, m.ModifyCalls(), 1)
+	require.Len(t, b.pending, 1)
+}
+
+func TestReadKey(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("j\x1b[A\x1b[D\r\x03\x7fé"))
+
+	var keys []string
+	for {
+		key, err := readKey(r)
+		if err != nil {
+			break
+		}
+		keys = append(keys, key)
+	}
+	require.Equal(t, []string{"j", "up", "left", "enter", "ctrl-c", "backspace", "é"}, keys)
+}
+
+func TestBrowser_FlushRejected(t *testing.T) {
+	m := tuiMock(2)
+	m.ModifyFunc = func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+		return &pocket.ModifyResponse{Status: 1, ActionResult: []interface{}{true, false}}, nil
+	}
+
+	b := newBrowser(m, pocket.RetrieveInput{}, 3)
+	require.NoError(t, b.load(context.Background()))
+	b.handle(context.Background(), "a")
+	b.handle(context.Background(), "j")
+	b.handle(context.Background(), "f")
+
+	require.False(t, b.handle(context.Background(), "q"))
+	require.Equal(t, "1 of 2 actions failed: favorite of item 2; Q quits without saving", b.message)
+	require.Empty(t, b.pending)
+
+	// nothing is left to send
+	require.True(t, b.handle(context.Background(), "q"))
+	require.Len(t, m.ModifyCalls(), 1)
+}
+
+func TestBrowser_EmptyList(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Pocket sends an empty array when there are no items
+		_, err := w.Write([]byte(`{"status":2,"list":[]}`))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	b := newBrowser(pocket.New("consumer-key").WithBaseUrl(srv.URL), pocket.RetrieveInput{}, 3)
+	out := bytes.Buffer{}
+	require.NoError(t, b.run(context.Background(), strings.NewReader("nq"), &out))
+	require.Contains(t, out.String(), "No items")
+	require.Contains(t, out.String(), "last page")
+}
@@ -0,0 +1,398 @@
+// Package dedupe finds items saved more than once and merges them.
+package dedupe
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+// titles with fewer words are too generic to compare, e.g. Home or Page not found
+const minTitleWords = 3
+
+// Group is one page saved several times.
+type Group struct {
+	Keep   pocket.RetrieveListItem   // the oldest item
+	Others []pocket.RetrieveListItem // merged into Keep and deleted, from the oldest
+}
+
+type Options struct {
+	// TitleSimilarity is the share of words two titles must have in common to be duplicates,
+	// 1 for the same words; titles are not compared if zero.
+	TitleSimilarity float64
+}
+
+// Find groups not deleted items with the same normalized url, the same resolved id or, with
+// Options.TitleSimilarity, similar titles. Groups are ordered by the time their oldest item was added.
+func Find(items []pocket.RetrieveListItem, opts Options) []Group {
+	live := make([]pocket.RetrieveListItem, 0, len(items))
+	for _, item := range items {
+		if item.Status != pocket.ItemStatusDeleted {
+			live = append(live, item)
+		}
+	}
+
+	parent := make([]int, len(live))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(i, j int) {
+		parent[find(j)] = find(i)
+	}
+
+	// items with the same key are duplicates
+	keys := map[string]int{}
+	join := func(i int, key string) {
+		if j, ok := keys[key]; ok {
+			union(j, i)
+			return
+		}
+		keys[key] = i
+	}
+	for i, item := range live {
+		if u := itemURL(item); u != "" {
+			join(i, "url:"+pocket.NormalizeURL(u))
+		}
+		if item.ResolvedID != "" && item.ResolvedID != "0" {
+			join(i, "id:"+item.ResolvedID)
+		}
+	}
+
+	members := map[int][]pocket.RetrieveListItem{}
+	for i, item := range live {
+		root := find(i)
+		members[root] = append(members[root], item)
+	}
+	clusters := make([][]pocket.RetrieveListItem, 0, len(members))
+	for _, g := range members {
+		sort.Slice(g, func(i, j int) bool {
+			return older(g[i], g[j])
+		})
+		clusters = append(clusters, g)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return older(clusters[i][0], clusters[j][0])
+	})
+
+	// a cluster joins the first older cluster with an item titled like its kept item; titles are compared
+	// with kept items only, so a chain of similar titles doesn't join unrelated pages
+	if opts.TitleSimilarity > 0 {
+		var joined [][]pocket.RetrieveListItem
+		var keepWords []map[string]bool
+		for _, g := range clusters {
+			words := make([]map[string]bool, len(g))
+			for i, item := range g {
+				words[i] = titleWords(item)
+			}
+			target := -1
+			for k := range joined {
+				for _, w := range words {
+					if similar(keepWords[k], w, opts.TitleSimilarity) {
+						target = k
+						break
+					}
+				}
+				if target >= 0 {
+					break
+				}
+			}
+			if target < 0 {
+				joined = append(joined, g)
+				keepWords = append(keepWords, words[0])
+				continue
+			}
+			joined[target] = append(joined[target], g...)
+		}
+		clusters = joined
+	}
+
+	var groups []Group
+	for _, g := range clusters {
+		if len(g) < 2 {
+			continue
+		}
+		sort.Slice(g, func(i, j int) bool {
+			return older(g[i], g[j])
+		})
+		groups = append(groups, Group{Keep: g[0], Others: g[1:]})
+	}
+	return groups
+}
+
+// Actions returns actions merging every group: tags of the others are added to the kept item,
+// it is favorited if any of the others is, and the others are deleted.
+func Actions(groups []Group) (pocket.Actions, error) {
+	now := time.Now().Unix()
+
+	var actions pocket.Actions
+	for _, g := range groups {
+		merge, deletes, err := groupActions(g, now)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, merge...)
+		actions = append(actions, deletes...)
+	}
+	return actions, nil
+}
+
+// groupActions returns the tags_add and favorite actions of the kept item and the delete actions of the others.
+func groupActions(g Group, now int64) (merge, deletes pocket.Actions, err error) {
+	keepID, err := itemID(g.Keep)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tags []string
+	seen := map[string]bool{}
+	favorite := false
+	for _, other := range g.Others {
+		for _, tag := range other.TagNames() {
+			if !g.Keep.HasTag(tag) && !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+		favorite = favorite || other.IsFavorite()
+	}
+
+	if len(tags) != 0 {
+		sort.Strings(tags)
+		merge = append(merge, &pocket.ActionTagsAdd{
+			Action: pocket.ActionTagsAddType,
+			ItemID: keepID,
+			Tags:   strings.Join(tags, ","),
+			Time:   now,
+		})
+	}
+	if favorite && !g.Keep.IsFavorite() {
+		merge = append(merge, &pocket.ActionFavorite{
+			Action: pocket.ActionFavoriteType,
+			ItemID: keepID,
+			Time:   now,
+		})
+	}
+	for _, other := range g.Others {
+		id, err := itemID(other)
+		if err != nil {
+			return nil, nil, err
+		}
+		deletes = append(deletes, &pocket.ActionDelete{
+			Action: pocket.ActionDeleteType,
+			ItemID: id,
+			Time:   now,
+		})
+	}
+	return merge, deletes, nil
+}
+
+// Report is the result of Merge.
+type Report struct {
+	Groups  []Group
+	Actions pocket.Actions
+	Sent    int      // number of actions sent, zero for a dry run
+	Failed  []string // actions rejected by Pocket
+	Skipped int      // deletes not sent because merging tags or favorite into their kept item failed
+	DryRun  bool
+}
+
+func (r *Report) String() string {
+	sb := strings.Builder{}
+	for _, g := range r.Groups {
+		fmt.Fprintf(&sb, "keep %s %s\n", g.Keep.ItemID, itemURL(g.Keep))
+		for _, other := range g.Others {
+			fmt.Fprintf(&sb, "  delete %s %s\n", other.ItemID, itemURL(other))
+		}
+	}
+	switch {
+	case len(r.Groups) == 0:
+		sb.WriteString("no duplicates\n")
+	case r.DryRun:
+		fmt.Fprintf(&sb, "dry run, %d actions not sent\n", len(r.Actions))
+	default:
+		for _, f := range r.Failed {
+			fmt.Fprintf(&sb, "%s failed\n", f)
+		}
+		fmt.Fprintf(&sb, "%d actions sent", r.Sent)
+		if len(r.Failed) != 0 {
+			fmt.Fprintf(&sb, ", %d failed", len(r.Failed))
+		}
+		if r.Skipped != 0 {
+			fmt.Fprintf(&sb, ", %d deletes skipped", r.Skipped)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Merge sends the actions merging the groups, a dry run only reports them.
+// Tags and favorites are sent first and the others of a group are deleted with a second Modify call only
+// if Pocket accepted merging their kept item, so rejected actions never lose tags.
+// Rejected actions are listed in Report.Failed.
+func Merge(ctx context.Context, c pocket.Client, groups []Group, dryRun bool) (*Report, error) {
+	now := time.Now().Unix()
+
+	var merges, actions pocket.Actions
+	var mergeGroup []int // group of every merge action
+	deletes := make([]pocket.Actions, len(groups))
+	for i, g := range groups {
+		merge, del, err := groupActions(g, now)
+		if err != nil {
+			return nil, err
+		}
+		for range merge {
+			mergeGroup = append(mergeGroup, i)
+		}
+		merges = append(merges, merge...)
+		deletes[i] = del
+		actions = append(actions, merge...)
+		actions = append(actions, del...)
+	}
+
+	r := &Report{
+		Groups:  groups,
+		Actions: actions,
+		DryRun:  dryRun,
+	}
+	if dryRun || len(actions) == 0 {
+		return r, nil
+	}
+
+	merged := make([]bool, len(groups))
+	for i := range merged {
+		merged[i] = true
+	}
+	if len(merges) != 0 {
+		res, err := c.Modify(ctx, merges)
+		if err != nil {
+			return r, fmt.Errorf("error while merging duplicates: %w", err)
+		}
+		r.Sent += len(merges)
+		for _, i := range r.reject(merges, res) {
+			merged[mergeGroup[i]] = false
+		}
+	}
+
+	var dels pocket.Actions
+	for i, del := range deletes {
+		if !merged[i] {
+			r.Skipped += len(del)
+			continue
+		}
+		dels = append(dels, del...)
+	}
+	if len(dels) == 0 {
+		return r, nil
+	}
+	res, err := c.Modify(ctx, dels)
+	if err != nil {
+		return r, fmt.Errorf("error while deleting duplicates: %w", err)
+	}
+	r.Sent += len(dels)
+	r.reject(dels, res)
+	return r, nil
+}
+
+// reject adds actions with false results or errors in the response to Failed and returns their indexes.
+func (r *Report) reject(actions pocket.Actions, res *pocket.ModifyResponse) []int {
+	var failed []int
+	for i, a := range actions {
+		ok := true
+		if i < len(res.ActionResult) && res.ActionResult[i] == false {
+			ok = false
+		}
+		if i < len(res.ActionErrors) && res.ActionErrors[i] != nil {
+			ok = false
+		}
+		if ok {
+			continue
+		}
+		failed = append(failed, i)
+		f, _ := pocket.ParseAction(a)
+		r.Failed = append(r.Failed, fmt.Sprintf("%s of item %d", f.Action, f.ItemID))
+	}
+	return failed
+}
+
+func itemURL(item pocket.RetrieveListItem) string {
+	if item.ResolvedURL != "" {
+		return item.ResolvedURL
+	}
+	return item.GivenURL
+}
+
+func itemID(item pocket.RetrieveListItem) (int64, error) {
+	id, err := strconv.ParseInt(item.ItemID, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error while parsing item id %q: %w", item.ItemID, err)
+	}
+	return id, nil
+}
+
+// older reports whether a was added before b, items added at the same time are ordered by id.
+func older(a, b pocket.RetrieveListItem) bool {
+	ta, tb := a.TimeAddedAt(), b.TimeAddedAt()
+	if !ta.Equal(tb) {
+		return ta.Before(tb)
+	}
+	ia, _ := strconv.ParseInt(a.ItemID, 10, 64)
+	ib, _ := strconv.ParseInt(b.ItemID, 10, 64)
+	return ia < ib
+}
+
+// titleWords returns lower case words of the title, nil for titles too short to compare.
+func titleWords(item pocket.RetrieveListItem) map[string]bool {
+	title := item.ResolvedTitle
+	if title == "" {
+		title = item.GivenTitle
+	}
+
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(fields) < minTitleWords {
+		return nil
+	}
+
+	words := make(map[string]bool, len(fields))
+	for _, w := range fields {
+		words[w] = true
+	}
+	return words
+}
+
+// similar reports whether the Jaccard index of the word sets is at least threshold.
+func similar(a, b map[string]bool, threshold float64) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	small, large := a, b
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	// the index is at most len(small)/len(large)
+	if float64(len(small)) < threshold*float64(len(large)) {
+		return false
+	}
+
+	common := 0
+	for w := range small {
+		if large[w] {
+			common++
+		}
+	}
+	return float64(common) >= threshold*float64(len(a)+len(b)-common)
+}
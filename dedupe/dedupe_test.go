@@ -0,0 +1,217 @@
+package dedupe
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/pocketmock"
+	"github.com/stretchr/testify/require"
+)
+
+func ids(groups []Group) [][]string {
+	var res [][]string
+	for _, g := range groups {
+		group := []string{g.Keep.ItemID}
+		for _, other := range g.Others {
+			group = append(group, other.ItemID)
+		}
+		res = append(res, group)
+	}
+	return res
+}
+
+func TestFind(t *testing.T) {
+	items := []pocket.RetrieveListItem{
+		{ItemID: "1", TimeAdded: "300", ResolvedURL: "https://example.com/post"},
+		{ItemID: "2", TimeAdded: "100", GivenURL: "http://www.example.com/post/?utm_source=twitter"},
+		{ItemID: "3", TimeAdded: "200", ResolvedURL: "https://example.com/post/amp"},
+		{ItemID: "4", TimeAdded: "400", ResolvedURL: "https://example.com/other", ResolvedID: "77"},
+		{ItemID: "5", TimeAdded: "50", ResolvedURL: "https://short.example/x", ResolvedID: "77"},
+		{ItemID: "6", TimeAdded: "10", ResolvedURL: "https://news.example/a", ResolvedTitle: "Go 2 generics: the final design"},
+		{ItemID: "7", TimeAdded: "20", ResolvedURL: "https://mirror.example/a", ResolvedTitle: "Go 2 Generics - The Final Design"},
+		{ItemID: "8", TimeAdded: "30", ResolvedURL: "https://a.example", ResolvedTitle: "Home"},
+		{ItemID: "9", TimeAdded: "40", ResolvedURL: "https://b.example", ResolvedTitle: "Home"},
+		{ItemID: "10", TimeAdded: "1", ResolvedURL: "https://example.com/post", Status: pocket.ItemStatusDeleted},
+		{ItemID: "11", TimeAdded: "5", ResolvedURL: "https://unique.example", ResolvedID: "0"},
+		{ItemID: "12", TimeAdded: "6", ResolvedURL: "https://unique.example/b", ResolvedID: "0"},
+		// each title is similar to the next one only
+		{ItemID: "13", TimeAdded: "60", ResolvedURL: "https://c.example/1", ResolvedTitle: "one two three four five"},
+		{ItemID: "14", TimeAdded: "61", ResolvedURL: "https://c.example/2", ResolvedTitle: "one two three four five six"},
+		{ItemID: "15", TimeAdded: "62", ResolvedURL: "https://c.example/3", ResolvedTitle: "one two three four five six seven"},
+		{ItemID: "16", TimeAdded: "63", ResolvedURL: "https://c.example/4", ResolvedTitle: "one two three four five six seven eight"},
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		exp  [][]string
+	}{
+		{
+			name: "urls and resolved ids",
+			exp:  [][]string{{"5", "4"}, {"2", "3", "1"}},
+		},
+		{
+			name: "similar titles",
+			opts: Options{TitleSimilarity: 0.8},
+			exp:  [][]string{{"6", "7"}, {"5", "4"}, {"13", "14"}, {"15", "16"}, {"2", "3", "1"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.exp, ids(Find(items, tc.opts)))
+		})
+	}
+}
+
+func TestSimilar(t *testing.T) {
+	words := func(title string) map[string]bool {
+		return titleWords(pocket.RetrieveListItem{GivenTitle: title})
+	}
+
+	require.True(t, similar(words("a b c d"), words("A, b, c, d!"), 1))
+	require.True(t, similar(words("a b c d e"), words("a b c d"), 0.8))
+	require.False(t, similar(words("a b c d e"), words("a b c"), 0.8))
+	require.False(t, similar(words("a b"), words("a b"), 0.5))
+}
+
+func TestActions(t *testing.T) {
+	tags := func(names ...string) map[string]pocket.Tag {
+		res := map[string]pocket.Tag{}
+		for _, name := range names {
+			res[name] = pocket.Tag{Tag: name}
+		}
+		return res
+	}
+
+	actions, err := Actions([]Group{
+		{
+			Keep: pocket.RetrieveListItem{ItemID: "1", Tags: tags("go")},
+			Others: []pocket.RetrieveListItem{
+				{ItemID: "2", Tags: tags("go", "news"), Favorite: "1"},
+				{ItemID: "3", Tags: tags("blog", "news")},
+			},
+		},
+		{
+			Keep:   pocket.RetrieveListItem{ItemID: "4", Favorite: "1"},
+			Others: []pocket.RetrieveListItem{{ItemID: "5", Favorite: "1"}},
+		},
+	})
+	require.NoError(t, err)
+
+	var got []pocket.ActionFields
+	for _, a := range actions {
+		f, err := pocket.ParseAction(a)
+		require.NoError(t, err)
+		require.NotZero(t, f.Time)
+		f.Time = 0
+		got = append(got, f)
+	}
+	require.Equal(t, []pocket.ActionFields{
+		{Action: pocket.ActionTagsAddType, ItemID: 1, Tags: "blog,news"},
+		{Action: pocket.ActionFavoriteType, ItemID: 1},
+		{Action: pocket.ActionDeleteType, ItemID: 2},
+		{Action: pocket.ActionDeleteType, ItemID: 3},
+		{Action: pocket.ActionDeleteType, ItemID: 5},
+	}, got)
+
+	_, err = Actions([]Group{{Keep: pocket.RetrieveListItem{ItemID: "x"}}})
+	require.Error(t, err)
+}
+
+func TestMerge(t *testing.T) {
+	groups := []Group{{
+		Keep:   pocket.RetrieveListItem{ItemID: "1", ResolvedURL: "https://example.com/a"},
+		Others: []pocket.RetrieveListItem{{ItemID: "2", GivenURL: "https://example.com/a?utm_source=hn"}},
+	}}
+
+	t.Run("dry run", func(t *testing.T) {
+		m := &pocketmock.MockClient{}
+		r, err := Merge(context.Background(), m, groups, true)
+		require.NoError(t, err)
+		require.Empty(t, m.ModifyCalls())
+		require.Equal(t, "keep 1 https://example.com/a\n"+
+			"  delete 2 https://example.com/a?utm_source=hn\n"+
+			"dry run, 1 actions not sent\n", r.String())
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		m := &pocketmock.MockClient{
+			ModifyFunc: func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+				return &pocket.ModifyResponse{Status: 1, ActionResult: []interface{}{true}}, nil
+			},
+		}
+		r, err := Merge(context.Background(), m, groups, false)
+		require.NoError(t, err)
+		require.Equal(t, 1, r.Sent)
+		require.Empty(t, r.Failed)
+		require.Len(t, m.ModifyCalls(), 1)
+		require.True(t, strings.HasSuffix(r.String(), "\n1 actions sent\n"))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := Merge(context.Background(), &pocketmock.MockClient{}, groups, false)
+		require.True(t, errors.Is(err, pocketmock.ErrNotProgrammed))
+	})
+
+	t.Run("nothing", func(t *testing.T) {
+		r, err := Merge(context.Background(), &pocketmock.MockClient{}, nil, false)
+		require.NoError(t, err)
+		require.Equal(t, "no duplicates\n", r.String())
+	})
+}
+
+func TestMerge_Rejected(t *testing.T) {
+	groups := []Group{
+		{
+			Keep:   pocket.RetrieveListItem{ItemID: "1"},
+			Others: []pocket.RetrieveListItem{{ItemID: "2", Tags: map[string]pocket.Tag{"go": {Tag: "go"}}}},
+		},
+		{
+			Keep:   pocket.RetrieveListItem{ItemID: "3"},
+			Others: []pocket.RetrieveListItem{{ItemID: "4", Favorite: "1"}, {ItemID: "5"}},
+		},
+	}
+
+	m := &pocketmock.MockClient{
+		ModifyFunc: func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+			f, err := pocket.ParseAction(actions[0])
+			require.NoError(t, err)
+			if f.Action == pocket.ActionTagsAddType {
+				// tags of item 1 are not added, favorite of item 3 is
+				return &pocket.ModifyResponse{Status: 1, ActionResult: []interface{}{false, true}}, nil
+			}
+			return &pocket.ModifyResponse{
+				Status:       1,
+				ActionResult: []interface{}{true, false},
+				ActionErrors: []interface{}{nil, map[string]interface{}{"message": "Invalid item"}},
+			}, nil
+		},
+	}
+	r, err := Merge(context.Background(), m, groups, false)
+	require.NoError(t, err)
+
+	calls := m.ModifyCalls()
+	require.Len(t, calls, 2)
+	require.Len(t, calls[0].Actions, 2)
+	// item 2 is not deleted, its tags would be lost
+	var deleted []int64
+	for _, a := range calls[1].Actions {
+		f, err := pocket.ParseAction(a)
+		require.NoError(t, err)
+		require.Equal(t, pocket.ActionDeleteType, f.Action)
+		deleted = append(deleted, f.ItemID)
+	}
+	require.Equal(t, []int64{4, 5}, deleted)
+
+	require.Equal(t, 4, r.Sent)
+	require.Equal(t, 1, r.Skipped)
+	require.Equal(t, []string{"tags_add of item 1", "delete of item 5"}, r.Failed)
+	require.True(t, strings.HasSuffix(r.String(), "\n"+
+		"tags_add of item 1 failed\n"+
+		"delete of item 5 failed\n"+
+		"4 actions sent, 2 failed, 1 deletes skipped\n"), r.String())
+}
@@ -1,9 +1,14 @@
 package pocket
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 )
 
+var ErrEmptyRequestToken = errors.New("request token is empty, generate it first")
+
 type ErrorPocket struct {
 	Message  string
 	Xcode    string // see X-Code-Error here https://getpocket.com/developer/docs/authentication
@@ -21,3 +26,20 @@ func NewErrorPocket(message string, xCode string, httpCode int) *ErrorPocket {
 		HttpCode: httpCode,
 	}
 }
+
+// MultiError holds errors of operations performed for several users, keyed by user id.
+type MultiError map[string]error
+
+func (me MultiError) Error() string {
+	users := make([]string, 0, len(me))
+	for user := range me {
+		users = append(users, user)
+	}
+	sort.Strings(users)
+
+	msgs := make([]string, 0, len(users))
+	for _, user := range users {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", user, me[user]))
+	}
+	return strings.Join(msgs, "; ")
+}
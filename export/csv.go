@@ -0,0 +1,128 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+type Column string
+
+const (
+	ColumnURL       Column = "url"
+	ColumnTitle     Column = "title"
+	ColumnTags      Column = "tags"
+	ColumnStatus    Column = "status"
+	ColumnFavorite  Column = "favorite"
+	ColumnTimeAdded Column = "time_added"
+	ColumnWordCount Column = "word_count"
+	ColumnDomain    Column = "domain"
+)
+
+// values of the status column
+const (
+	StatusUnread   = "unread"
+	StatusArchived = "archived"
+)
+
+var DefaultColumns = []Column{
+	ColumnURL,
+	ColumnTitle,
+	ColumnTags,
+	ColumnStatus,
+	ColumnFavorite,
+	ColumnTimeAdded,
+	ColumnWordCount,
+	ColumnDomain,
+}
+
+type CSVOptions struct {
+	Columns      []Column // DefaultColumns if empty
+	TagSeparator string   // "," if empty, the csv writer quotes the field if needed
+	TimeFormat   string   // layout of time.Format in UTC, unix seconds if empty
+}
+
+func (o CSVOptions) tagSeparator() string {
+	if o.TagSeparator == "" {
+		return ","
+	}
+	return o.TagSeparator
+}
+
+// CSV writes items as CSV with a header row. Items are ordered from the newest, deleted items are skipped.
+func CSV(w io.Writer, items []pocket.RetrieveListItem, opts CSVOptions) error {
+	columns := opts.Columns
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = string(c)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("error while writing csv header: %w", err)
+	}
+
+	for _, item := range newestFirst(items) {
+		if item.Status == pocket.ItemStatusDeleted {
+			continue
+		}
+
+		record := make([]string, len(columns))
+		for i, c := range columns {
+			v, err := csvValue(item, c, opts)
+			if err != nil {
+				return err
+			}
+			record[i] = v
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("error while writing csv record: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvValue(item pocket.RetrieveListItem, c Column, opts CSVOptions) (string, error) {
+	switch c {
+	case ColumnURL:
+		return itemURL(item), nil
+	case ColumnTitle:
+		if item.ResolvedTitle != "" {
+			return item.ResolvedTitle, nil
+		}
+		return item.GivenTitle, nil
+	case ColumnTags:
+		return strings.Join(item.TagNames(), opts.tagSeparator()), nil
+	case ColumnStatus:
+		if item.Status == pocket.ItemStatusArchived {
+			return StatusArchived, nil
+		}
+		return StatusUnread, nil
+	case ColumnFavorite:
+		return strconv.FormatBool(item.IsFavorite()), nil
+	case ColumnTimeAdded:
+		added := item.TimeAddedAt()
+		switch {
+		case added.IsZero():
+			return "", nil
+		case opts.TimeFormat == "":
+			return strconv.FormatInt(added.Unix(), 10), nil
+		}
+		return added.UTC().Format(opts.TimeFormat), nil
+	case ColumnWordCount:
+		return strconv.Itoa(item.Words()), nil
+	case ColumnDomain:
+		return item.Domain(), nil
+	}
+	return "", fmt.Errorf("unknown csv column %q", c)
+}
@@ -0,0 +1,56 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSV(t *testing.T) {
+	items := testItems()
+	items[1].Favorite = "1"
+	items[1].WordCount = "1200"
+
+	tests := []struct {
+		name string
+		opts CSVOptions
+		want string
+	}{
+		{
+			name: "default",
+			want: `url,title,tags,status,favorite,time_added,word_count,domain
+https://newer.com,,,unread,false,1700000000,0,newer.com
+https://go.dev/?a=1&b=2,Go <Home>,"go,lang",unread,false,1600000000,0,go.dev
+https://example.com,Example,,archived,true,1500000000,1200,example.com
+`,
+		},
+		{
+			name: "options",
+			opts: CSVOptions{
+				Columns:      []Column{ColumnTitle, ColumnTags, ColumnTimeAdded},
+				TagSeparator: " ",
+				TimeFormat:   time.RFC3339,
+			},
+			want: `title,tags,time_added
+,,2023-11-14T22:13:20Z
+Go <Home>,go lang,2020-09-13T12:26:40Z
+Example,,2017-07-14T02:40:00Z
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			require.NoError(t, CSV(&buf, items, test.opts))
+			require.Equal(t, test.want, buf.String())
+		})
+	}
+}
+
+func TestCSV_UnknownColumn(t *testing.T) {
+	buf := bytes.Buffer{}
+	require.Error(t, CSV(&buf, testItems(), CSVOptions{Columns: []Column{"nope"}}))
+}
@@ -0,0 +1,21 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+// JSONLines writes every item as a JSON object on its own line, keeping all the retrieved fields.
+// Read the dump back with importer.ReadJSONLines.
+func JSONLines(w io.Writer, items []pocket.RetrieveListItem) error {
+	enc := json.NewEncoder(w)
+	for _, item := range items {
+		if err := enc.Encode(&item); err != nil {
+			return fmt.Errorf("error while encoding item %s: %w", item.ItemID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+const maxSlugLength = 60
+
+var slugRe = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// Markdown writes the item as Markdown with YAML front matter. Highlights are written
+// as block quotes, Retrieve returns them only with complete details.
+func Markdown(w io.Writer, item pocket.RetrieveListItem) error {
+	bw := bufio.NewWriter(w)
+
+	status := StatusUnread
+	if item.Status == pocket.ItemStatusArchived {
+		status = StatusArchived
+	}
+
+	bw.WriteString("---\n")
+	bw.WriteString("title: " + strconv.Quote(itemTitle(item)) + "\n")
+	bw.WriteString("url: " + strconv.Quote(itemURL(item)) + "\n")
+	bw.WriteString("item_id: " + strconv.Quote(item.ItemID) + "\n")
+	bw.WriteString("status: " + status + "\n")
+	bw.WriteString("favorite: " + strconv.FormatBool(item.IsFavorite()) + "\n")
+	writeYAMLList(bw, "tags", item.TagNames())
+	var authors []string
+	for _, a := range sortedAuthors(item) {
+		authors = append(authors, a.Name)
+	}
+	writeYAMLList(bw, "authors", authors)
+	writeYAMLTime(bw, "added", item.TimeAddedAt())
+	writeYAMLTime(bw, "updated", item.TimeUpdatedAt())
+	writeYAMLTime(bw, "read", item.TimeReadAt())
+	writeYAMLTime(bw, "favorited", item.TimeFavoritedAt())
+	if item.Words() != 0 {
+		bw.WriteString("word_count: " + strconv.Itoa(item.Words()) + "\n")
+	}
+	if item.TimeToRead != 0 {
+		bw.WriteString("time_to_read: " + strconv.Itoa(item.TimeToRead) + "\n")
+	}
+	bw.WriteString("---\n\n")
+
+	bw.WriteString("# " + itemTitle(item) + "\n")
+	if item.Excerpt != "" {
+		bw.WriteString("\n" + item.Excerpt + "\n")
+	}
+
+	annotations := append([]pocket.Annotation(nil), item.Annotations...)
+	sort.SliceStable(annotations, func(i, j int) bool {
+		return annotations[i].CreatedAt < annotations[j].CreatedAt
+	})
+	if len(annotations) != 0 {
+		bw.WriteString("\n## Highlights\n")
+	}
+	for _, a := range annotations {
+		bw.WriteString("\n")
+		for _, line := range strings.Split(strings.TrimSpace(a.Quote), "\n") {
+			if line = strings.TrimSpace(line); line == "" {
+				bw.WriteString(">\n")
+			} else {
+				bw.WriteString("> " + line + "\n")
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// MarkdownFileName returns the name of the file of the item, the item id and a slug of the title.
+func MarkdownFileName(item pocket.RetrieveListItem) string {
+	slug := strings.Trim(slugRe.ReplaceAllString(strings.ToLower(itemTitle(item)), "-"), "-")
+	if r := []rune(slug); len(r) > maxSlugLength {
+		slug = strings.TrimRight(string(r[:maxSlugLength]), "-")
+	}
+	if slug == "" {
+		return item.ItemID + ".md"
+	}
+	return item.ItemID + "-" + slug + ".md"
+}
+
+// MarkdownDir writes every not deleted item to its own file in the directory, creating the directory if needed.
+func MarkdownDir(dir string, items []pocket.RetrieveListItem) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("error while creating directory: %w", err)
+	}
+
+	for _, item := range items {
+		if item.Status == pocket.ItemStatusDeleted {
+			continue
+		}
+		if err := writeMarkdownFile(filepath.Join(dir, MarkdownFileName(item)), item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeMarkdownFile(path string, item pocket.RetrieveListItem) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error while creating markdown file: %w", err)
+	}
+	if err := Markdown(f, item); err != nil {
+		f.Close()
+		return fmt.Errorf("error while writing markdown file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("error while closing markdown file: %w", err)
+	}
+	return nil
+}
+
+func writeYAMLList(w *bufio.Writer, key string, values []string) {
+	if len(values) == 0 {
+		w.WriteString(key + ": []\n")
+		return
+	}
+	w.WriteString(key + ":\n")
+	for _, v := range values {
+		w.WriteString("  - " + strconv.Quote(v) + "\n")
+	}
+}
+
+func writeYAMLTime(w *bufio.Writer, key string, t time.Time) {
+	if !t.IsZero() {
+		w.WriteString(key + ": " + t.UTC().Format(time.RFC3339) + "\n")
+	}
+}
+
+// sortedAuthors returns named authors of the item sorted by id.
+func sortedAuthors(item pocket.RetrieveListItem) []pocket.Author {
+	res := make([]pocket.Author, 0, len(item.Authors))
+	for _, a := range item.Authors {
+		if a.Name != "" {
+			res = append(res, a)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].AuthorID < res[j].AuthorID
+	})
+	return res
+}
@@ -0,0 +1,81 @@
+package export
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdown(t *testing.T) {
+	item := pocket.RetrieveListItem{
+		ItemID:        "1",
+		Status:        pocket.ItemStatusArchived,
+		GivenURL:      "https://go.dev",
+		ResolvedTitle: `Go "the language"`,
+		Excerpt:       "Build simple software.",
+		Favorite:      "1",
+		TimeAdded:     "1600000000",
+		TimeRead:      "1700000000",
+		WordCount:     "1500",
+		TimeToRead:    7,
+		Tags:          map[string]pocket.Tag{"go": {Tag: "go"}, "lang": {Tag: "lang"}},
+		Authors:       map[string]pocket.Author{"1": {AuthorID: "1", Name: "Rob"}},
+		Annotations: []pocket.Annotation{
+			{AnnotationID: "b", Quote: "second", CreatedAt: "2020-07-17 10:00:00"},
+			{AnnotationID: "a", Quote: "first line\n\nnext paragraph", CreatedAt: "2020-07-17 09:00:07"},
+		},
+	}
+
+	buf := bytes.Buffer{}
+	require.NoError(t, Markdown(&buf, item))
+	require.Equal(t, `---
+title: "Go \"the language\""
+url: "https://go.dev"
+item_id: "1"
+status: archived
+favorite: true
+tags:
+  - "go"
+  - "lang"
+authors:
+  - "Rob"
+added: 2020-09-13T12:26:40Z
+read: 2023-11-14T22:13:20Z
+word_count: 1500
+time_to_read: 7
+---
+
+# Go "the language"
+
+Build simple software.
+
+## Highlights
+
+> first line
+>
+> next paragraph
+
+> second
+`, buf.String())
+}
+
+func TestMarkdownDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "notes")
+	require.NoError(t, MarkdownDir(dir, testItems()))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	require.Equal(t, []string{"1-go-home.md", "2-example.md", "3-https-newer-com.md"}, names)
+
+	data, err := os.ReadFile(filepath.Join(dir, "2-example.md"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "tags: []\n")
+}
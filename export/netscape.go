@@ -0,0 +1,84 @@
+// Package export writes retrieved items in formats other tools can read.
+package export
+
+import (
+	"bufio"
+	"html"
+	"io"
+	"sort"
+	"strings"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+// folder names used by Pocket in its export
+const (
+	UnreadFolder  = "Unread"
+	ArchiveFolder = "Read Archive"
+)
+
+const netscapeHeader = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<!--This is an automatically generated file.
+It will be read and overwritten.
+Do Not Edit! -->
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Pocket Export</TITLE>
+<H1>Pocket Export</H1>
+<DL><p>
+`
+
+// Netscape writes items as Netscape bookmark HTML, unread and archived items in separate folders.
+// Items are ordered from the newest, deleted items are skipped. Use store.Store.All to export a local store.
+func Netscape(w io.Writer, items []pocket.RetrieveListItem) error {
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString(netscapeHeader)
+	for _, folder := range []struct {
+		name   string
+		status string
+	}{
+		{UnreadFolder, pocket.ItemStatusUnread},
+		{ArchiveFolder, pocket.ItemStatusArchived},
+	} {
+		bw.WriteString("    <DT><H3>" + folder.name + "</H3>\n")
+		bw.WriteString("    <DL><p>\n")
+		for _, item := range newestFirst(items) {
+			if item.Status != folder.status {
+				continue
+			}
+			bw.WriteString(`        <DT><A HREF="` + html.EscapeString(itemURL(item)) +
+				`" ADD_DATE="` + html.EscapeString(item.TimeAdded) +
+				`" TAGS="` + html.EscapeString(strings.Join(item.TagNames(), ",")) + `">` +
+				html.EscapeString(itemTitle(item)) + "</A>\n")
+		}
+		bw.WriteString("    </DL><p>\n")
+	}
+	bw.WriteString("</DL><p>\n")
+
+	return bw.Flush()
+}
+
+func newestFirst(items []pocket.RetrieveListItem) []pocket.RetrieveListItem {
+	sorted := append([]pocket.RetrieveListItem(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].TimeAddedAt().After(sorted[j].TimeAddedAt())
+	})
+	return sorted
+}
+
+func itemURL(item pocket.RetrieveListItem) string {
+	if item.GivenURL != "" {
+		return item.GivenURL
+	}
+	return item.ResolvedURL
+}
+
+func itemTitle(item pocket.RetrieveListItem) string {
+	switch {
+	case item.ResolvedTitle != "":
+		return item.ResolvedTitle
+	case item.GivenTitle != "":
+		return item.GivenTitle
+	}
+	return itemURL(item)
+}
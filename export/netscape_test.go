@@ -0,0 +1,56 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func testItems() []pocket.RetrieveListItem {
+	return []pocket.RetrieveListItem{
+		{
+			ItemID:        "1",
+			Status:        pocket.ItemStatusUnread,
+			GivenURL:      "https://go.dev/?a=1&b=2",
+			ResolvedTitle: "Go <Home>",
+			TimeAdded:     "1600000000",
+			Tags:          map[string]pocket.Tag{"go": {Tag: "go"}, "lang": {Tag: "lang"}},
+		},
+		{
+			ItemID:      "2",
+			Status:      pocket.ItemStatusArchived,
+			ResolvedURL: "https://example.com",
+			GivenTitle:  "Example",
+			TimeAdded:   "1500000000",
+		},
+		{
+			ItemID:    "3",
+			Status:    pocket.ItemStatusUnread,
+			GivenURL:  "https://newer.com",
+			TimeAdded: "1700000000",
+		},
+		{
+			ItemID: "4",
+			Status: pocket.ItemStatusDeleted,
+		},
+	}
+}
+
+func TestNetscape(t *testing.T) {
+	buf := bytes.Buffer{}
+	require.NoError(t, Netscape(&buf, testItems()))
+
+	require.Equal(t, netscapeHeader+`    <DT><H3>Unread</H3>
+    <DL><p>
+        <DT><A HREF="https://newer.com" ADD_DATE="1700000000" TAGS="">https://newer.com</A>
+        <DT><A HREF="https://go.dev/?a=1&amp;b=2" ADD_DATE="1600000000" TAGS="go,lang">Go &lt;Home&gt;</A>
+    </DL><p>
+    <DT><H3>Read Archive</H3>
+    <DL><p>
+        <DT><A HREF="https://example.com" ADD_DATE="1500000000" TAGS="">Example</A>
+    </DL><p>
+</DL><p>
+`, buf.String())
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+// Domain is a site items were saved from.
+type Domain struct {
+	Domain string // host without www.
+	Name   string // name from domain_metadata, if Pocket knows it
+	Count  int
+}
+
+// Domains returns domains of not deleted items ordered from the most saved.
+func Domains(items []pocket.RetrieveListItem) []Domain {
+	byDomain := map[string]*Domain{}
+	for _, item := range items {
+		host := item.Domain()
+		if host == "" || item.Status == pocket.ItemStatusDeleted {
+			continue
+		}
+
+		d, ok := byDomain[host]
+		if !ok {
+			d = &Domain{Domain: host}
+			byDomain[host] = d
+		}
+		d.Count++
+		if d.Name == "" {
+			d.Name = item.DomainMetadata.Name
+		}
+	}
+
+	domains := make([]Domain, 0, len(byDomain))
+	for _, d := range byDomain {
+		domains = append(domains, *d)
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		if domains[i].Count != domains[j].Count {
+			return domains[i].Count > domains[j].Count
+		}
+		return domains[i].Domain < domains[j].Domain
+	})
+	return domains
+}
+
+type opmlDoc struct {
+	XMLName xml.Name      `xml:"opml"`
+	Version string        `xml:"version,attr"`
+	Title   string        `xml:"head>title"`
+	Body    []opmlOutline `xml:"body>outline"`
+}
+
+type opmlOutline struct {
+	Text  string `xml:"text,attr"`
+	Title string `xml:"title,attr,omitempty"`
+	Type  string `xml:"type,attr"`
+	URL   string `xml:"url,attr"`
+	Count string `xml:"count,attr"`
+}
+
+// OPML writes domains of the items as OPML 2.0 link outlines with the number of items in the count attribute.
+func OPML(w io.Writer, items []pocket.RetrieveListItem, title string) error {
+	doc := opmlDoc{Version: "2.0", Title: title}
+	for _, d := range Domains(items) {
+		o := opmlOutline{
+			Text:  d.Domain,
+			Type:  "link",
+			URL:   "https://" + d.Domain,
+			Count: strconv.Itoa(d.Count),
+		}
+		if d.Name != "" {
+			o.Text = d.Name
+			o.Title = d.Name
+		}
+		doc.Body = append(doc.Body, o)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("error while writing opml: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("error while encoding opml: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("error while writing opml: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOPML(t *testing.T) {
+	items := []pocket.RetrieveListItem{
+		{ResolvedURL: "https://www.theverge.com/a", DomainMetadata: pocket.DomainMetadata{Name: "The Verge"}},
+		{GivenURL: "https://theverge.com/b"},
+		{GivenURL: "https://go.dev/doc"},
+		{GivenURL: "https://deleted.com", Status: pocket.ItemStatusDeleted},
+		{GivenURL: "not a url"},
+	}
+
+	require.Equal(t, []Domain{
+		{Domain: "theverge.com", Name: "The Verge", Count: 2},
+		{Domain: "go.dev", Count: 1},
+	}, Domains(items))
+
+	buf := bytes.Buffer{}
+	require.NoError(t, OPML(&buf, items, "Pocket sites"))
+	require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head>
+    <title>Pocket sites</title>
+  </head>
+  <body>
+    <outline text="The Verge" title="The Verge" type="link" url="https://theverge.com" count="2"></outline>
+    <outline text="go.dev" type="link" url="https://go.dev" count="1"></outline>
+  </body>
+</opml>
+`, buf.String())
+}
@@ -0,0 +1,56 @@
+package export
+
+import (
+	"bufio"
+	"html"
+	"io"
+	"strings"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+const (
+	pocketHTMLHeader = `<!DOCTYPE html>
+<html>
+	<head>
+		<meta http-equiv="Content-Type" content="text/html; charset=UTF-8" />
+		<title>Pocket Export</title>
+	</head>
+	<body>
+`
+	pocketHTMLFooter = `	</body>
+</html>
+`
+)
+
+// PocketHTML writes items in the format of ril_export.html made by Pocket's own export:
+// an <h1> section with a list of links for unread and for archived items.
+// Items are ordered from the newest, deleted items are skipped.
+func PocketHTML(w io.Writer, items []pocket.RetrieveListItem) error {
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString(pocketHTMLHeader)
+	for _, section := range []struct {
+		name   string
+		status string
+	}{
+		{UnreadFolder, pocket.ItemStatusUnread},
+		{ArchiveFolder, pocket.ItemStatusArchived},
+	} {
+		bw.WriteString("\t\t<h1>" + section.name + "</h1>\n")
+		bw.WriteString("\t\t<ul>\n")
+		for _, item := range newestFirst(items) {
+			if item.Status != section.status {
+				continue
+			}
+			bw.WriteString(`			<li><a href="` + html.EscapeString(itemURL(item)) +
+				`" time_added="` + html.EscapeString(item.TimeAdded) +
+				`" tags="` + html.EscapeString(strings.Join(item.TagNames(), ",")) + `">` +
+				html.EscapeString(itemTitle(item)) + "</a></li>\n")
+		}
+		bw.WriteString("\t\t</ul>\n")
+	}
+	bw.WriteString(pocketHTMLFooter)
+
+	return bw.Flush()
+}
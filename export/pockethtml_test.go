@@ -0,0 +1,25 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPocketHTML(t *testing.T) {
+	buf := bytes.Buffer{}
+	require.NoError(t, PocketHTML(&buf, testItems()))
+
+	require.Equal(t, pocketHTMLHeader+`		<h1>Unread</h1>
+		<ul>
+			<li><a href="https://newer.com" time_added="1700000000" tags="">https://newer.com</a></li>
+			<li><a href="https://go.dev/?a=1&amp;b=2" time_added="1600000000" tags="go,lang">Go &lt;Home&gt;</a></li>
+		</ul>
+		<h1>Read Archive</h1>
+		<ul>
+			<li><a href="https://example.com" time_added="1500000000" tags="">Example</a></li>
+		</ul>
+`+pocketHTMLFooter, buf.String())
+
+}
@@ -0,0 +1,357 @@
+// Package feed publishes retrieved items as Atom and RSS feeds.
+package feed
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+type Format string
+
+const (
+	Atom Format = "atom"
+	RSS  Format = "rss"
+)
+
+const (
+	defaultTitle  = "Pocket"
+	defaultLink   = "https://getpocket.com/saves"
+	defaultAuthor = "Pocket"
+	defaultLimit  = 50
+)
+
+// ContentType returns the media type of the format.
+func (f Format) ContentType() string {
+	if f == RSS {
+		return "application/rss+xml; charset=utf-8"
+	}
+	return "application/atom+xml; charset=utf-8"
+}
+
+// Feed is a feed document. Zero fields get defaults when the feed is written.
+type Feed struct {
+	Title       string
+	Link        string // the page of the feed, also the Atom id
+	Description string
+	Author      string    // the author of the feed, Atom requires it if entries have no authors
+	Updated     time.Time // the newest entry date if zero
+	// date entries with the time an item was favorited instead of the time it was added
+	ByFavorited bool
+	Items       []pocket.RetrieveListItem
+	Since       int64 // the since cursor of the Retrieve response the items come from
+}
+
+// Build retrieves items with the input and returns them as a feed. Complete details are
+// requested to get authors and images, at most 50 items are retrieved if in.Count is zero.
+func Build(ctx context.Context, c pocket.Client, in pocket.RetrieveInput) (*Feed, error) {
+	in.DetailType = pocket.Complete
+	if in.Count == 0 {
+		in.Count = defaultLimit
+	}
+	if in.Sort == "" {
+		in.Sort = pocket.Newest
+	}
+
+	res, err := c.Retrieve(ctx, &in)
+	if err != nil {
+		return nil, fmt.Errorf("error while retrieving feed items: %w", err)
+	}
+
+	f := &Feed{Since: int64(res.Since)}
+	for id, item := range res.List {
+		if item.ItemID == "" {
+			item.ItemID = id
+		}
+		f.Items = append(f.Items, item)
+	}
+	sort.Slice(f.Items, func(i, j int) bool {
+		return f.Items[i].SortID < f.Items[j].SortID
+	})
+	return f, nil
+}
+
+// Write writes the feed in the format.
+func (f *Feed) Write(w io.Writer, format Format) error {
+	switch format {
+	case Atom:
+		return f.WriteAtom(w)
+	case RSS:
+		return f.WriteRSS(w)
+	}
+	return fmt.Errorf("unknown feed format %q", format)
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  *atomPerson `xml:"author,omitempty"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published,omitempty"`
+	Links      []atomLink     `xml:"link"`
+	Authors    []atomPerson   `xml:"author"`
+	Summary    string         `xml:"summary,omitempty"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// WriteAtom writes the feed as Atom 1.0.
+func (f *Feed) WriteAtom(w io.Writer) error {
+	doc := atomFeed{
+		Title:   f.title(),
+		ID:      f.link(),
+		Updated: f.updated().UTC().Format(time.RFC3339),
+		Links:   []atomLink{{Href: f.link(), Rel: "alternate"}},
+		Author:  &atomPerson{Name: f.author()},
+	}
+
+	for _, item := range f.items() {
+		e := atomEntry{
+			Title:   itemTitle(item),
+			ID:      itemURL(item),
+			Updated: f.date(item).UTC().Format(time.RFC3339),
+			Links:   []atomLink{{Href: itemURL(item), Rel: "alternate"}},
+			Summary: item.Excerpt,
+		}
+		if added := item.TimeAddedAt(); !added.IsZero() {
+			e.Published = added.UTC().Format(time.RFC3339)
+		}
+		if item.Image.Src != "" {
+			e.Links = append(e.Links, atomLink{Href: item.Image.Src, Rel: "enclosure", Type: imageType(item.Image.Src)})
+		}
+		for _, a := range authors(item) {
+			e.Authors = append(e.Authors, atomPerson{Name: a.Name, URI: a.URL})
+		}
+		for _, tag := range item.TagNames() {
+			e.Categories = append(e.Categories, atomCategory{Term: tag})
+		}
+		doc.Entries = append(doc.Entries, e)
+	}
+
+	return writeXML(w, doc)
+}
+
+type rssDoc struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	DC      string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        rssGUID       `xml:"guid"`
+	Description string        `xml:"description,omitempty"`
+	Creators    []string      `xml:"dc:creator"`
+	Categories  []string      `xml:"category"`
+	Enclosure   *rssEnclosure `xml:"enclosure,omitempty"`
+	PubDate     string        `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int    `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// WriteRSS writes the feed as RSS 2.0. Authors are written as dc:creator, RSS author requires an email.
+func (f *Feed) WriteRSS(w io.Writer) error {
+	doc := rssDoc{
+		Version: "2.0",
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:         f.title(),
+			Link:          f.link(),
+			Description:   f.Description,
+			LastBuildDate: f.updated().UTC().Format(time.RFC1123Z),
+		},
+	}
+
+	for _, item := range f.items() {
+		it := rssItem{
+			Title:       itemTitle(item),
+			Link:        itemURL(item),
+			GUID:        rssGUID{IsPermaLink: true, Value: itemURL(item)},
+			Description: item.Excerpt,
+			Categories:  item.TagNames(),
+			PubDate:     f.date(item).UTC().Format(time.RFC1123Z),
+		}
+		if item.Image.Src != "" {
+			it.Enclosure = &rssEnclosure{URL: item.Image.Src, Type: imageType(item.Image.Src)}
+		}
+		for _, a := range authors(item) {
+			it.Creators = append(it.Creators, a.Name)
+		}
+		doc.Channel.Items = append(doc.Channel.Items, it)
+	}
+
+	return writeXML(w, doc)
+}
+
+func writeXML(w io.Writer, doc interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("error while writing feed: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("error while encoding feed: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("error while writing feed: %w", err)
+	}
+	return nil
+}
+
+func (f *Feed) title() string {
+	if f.Title == "" {
+		return defaultTitle
+	}
+	return f.Title
+}
+
+func (f *Feed) link() string {
+	if f.Link == "" {
+		return defaultLink
+	}
+	return f.Link
+}
+
+func (f *Feed) author() string {
+	if f.Author == "" {
+		return defaultAuthor
+	}
+	return f.Author
+}
+
+// items returns not deleted items ordered from the newest date.
+func (f *Feed) items() []pocket.RetrieveListItem {
+	items := make([]pocket.RetrieveListItem, 0, len(f.Items))
+	for _, item := range f.Items {
+		if item.Status != pocket.ItemStatusDeleted {
+			items = append(items, item)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return f.date(items[i]).After(f.date(items[j]))
+	})
+	return items
+}
+
+func (f *Feed) updated() time.Time {
+	if !f.Updated.IsZero() {
+		return f.Updated
+	}
+
+	var updated time.Time
+	for _, item := range f.Items {
+		if d := f.date(item); d.After(updated) {
+			updated = d
+		}
+	}
+	if updated.IsZero() {
+		return time.Unix(0, 0)
+	}
+	return updated
+}
+
+func (f *Feed) date(item pocket.RetrieveListItem) time.Time {
+	if favorited := item.TimeFavoritedAt(); f.ByFavorited && !favorited.IsZero() {
+		return favorited
+	}
+	return item.TimeAddedAt()
+}
+
+// authors returns authors of the item sorted by id.
+func authors(item pocket.RetrieveListItem) []pocket.Author {
+	res := make([]pocket.Author, 0, len(item.Authors))
+	for _, a := range item.Authors {
+		if a.Name != "" {
+			res = append(res, a)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].AuthorID < res[j].AuthorID
+	})
+	return res
+}
+
+var imageTypes = map[string]string{
+	".gif":  "image/gif",
+	".png":  "image/png",
+	".svg":  "image/svg+xml",
+	".webp": "image/webp",
+}
+
+func imageType(src string) string {
+	u, err := url.Parse(src)
+	if err != nil {
+		return "image/jpeg"
+	}
+	if t, ok := imageTypes[strings.ToLower(path.Ext(u.Path))]; ok {
+		return t
+	}
+	return "image/jpeg"
+}
+
+func itemURL(item pocket.RetrieveListItem) string {
+	if item.ResolvedURL != "" {
+		return item.ResolvedURL
+	}
+	return item.GivenURL
+}
+
+func itemTitle(item pocket.RetrieveListItem) string {
+	switch {
+	case item.ResolvedTitle != "":
+		return item.ResolvedTitle
+	case item.GivenTitle != "":
+		return item.GivenTitle
+	}
+	return itemURL(item)
+}
@@ -0,0 +1,154 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/pocketmock"
+	"github.com/stretchr/testify/require"
+)
+
+func testFeed() *Feed {
+	return &Feed{
+		Title:       "Shared",
+		Link:        "https://example.com/shared",
+		Description: "Items tagged share",
+		Items: []pocket.RetrieveListItem{
+			{
+				ItemID:        "1",
+				GivenURL:      "http://go.dev",
+				ResolvedURL:   "https://go.dev/",
+				ResolvedTitle: "Go & you",
+				Excerpt:       "Build simple software.",
+				TimeAdded:     "1600000000",
+				TimeFavorited: "1700000000",
+				Authors: map[string]pocket.Author{
+					"2": {AuthorID: "2", Name: "Rob"},
+					"1": {AuthorID: "1", Name: "Ken", URL: "https://example.com/ken"},
+				},
+				Image: pocket.Image{Src: "https://go.dev/logo.png?v=1"},
+				Tags:  map[string]pocket.Tag{"share": {Tag: "share"}, "go": {Tag: "go"}},
+			},
+			{
+				ItemID:    "2",
+				GivenURL:  "https://example.com",
+				TimeAdded: "1650000000",
+			},
+			{
+				ItemID: "3",
+				Status: pocket.ItemStatusDeleted,
+			},
+		},
+	}
+}
+
+func TestFeed_WriteAtom(t *testing.T) {
+	buf := bytes.Buffer{}
+	require.NoError(t, testFeed().Write(&buf, Atom))
+
+	require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Shared</title>
+  <id>https://example.com/shared</id>
+  <updated>2022-04-15T05:20:00Z</updated>
+  <link href="https://example.com/shared" rel="alternate"></link>
+  <author>
+    <name>Pocket</name>
+  </author>
+  <entry>
+    <title>https://example.com</title>
+    <id>https://example.com</id>
+    <updated>2022-04-15T05:20:00Z</updated>
+    <published>2022-04-15T05:20:00Z</published>
+    <link href="https://example.com" rel="alternate"></link>
+  </entry>
+  <entry>
+    <title>Go &amp; you</title>
+    <id>https://go.dev/</id>
+    <updated>2020-09-13T12:26:40Z</updated>
+    <published>2020-09-13T12:26:40Z</published>
+    <link href="https://go.dev/" rel="alternate"></link>
+    <link href="https://go.dev/logo.png?v=1" rel="enclosure" type="image/png"></link>
+    <author>
+      <name>Ken</name>
+      <uri>https://example.com/ken</uri>
+    </author>
+    <author>
+      <name>Rob</name>
+    </author>
+    <summary>Build simple software.</summary>
+    <category term="go"></category>
+    <category term="share"></category>
+  </entry>
+</feed>
+`, buf.String())
+}
+
+func TestFeed_WriteRSS(t *testing.T) {
+	f := testFeed()
+	f.ByFavorited = true
+
+	buf := bytes.Buffer{}
+	require.NoError(t, f.Write(&buf, RSS))
+
+	require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <channel>
+    <title>Shared</title>
+    <link>https://example.com/shared</link>
+    <description>Items tagged share</description>
+    <lastBuildDate>Tue, 14 Nov 2023 22:13:20 +0000</lastBuildDate>
+    <item>
+      <title>Go &amp; you</title>
+      <link>https://go.dev/</link>
+      <guid isPermaLink="true">https://go.dev/</guid>
+      <description>Build simple software.</description>
+      <dc:creator>Ken</dc:creator>
+      <dc:creator>Rob</dc:creator>
+      <category>go</category>
+      <category>share</category>
+      <enclosure url="https://go.dev/logo.png?v=1" length="0" type="image/png"></enclosure>
+      <pubDate>Tue, 14 Nov 2023 22:13:20 +0000</pubDate>
+    </item>
+    <item>
+      <title>https://example.com</title>
+      <link>https://example.com</link>
+      <guid isPermaLink="true">https://example.com</guid>
+      <pubDate>Fri, 15 Apr 2022 05:20:00 +0000</pubDate>
+    </item>
+  </channel>
+</rss>
+`, buf.String())
+}
+
+func TestFeed_WriteUnknownFormat(t *testing.T) {
+	require.Error(t, testFeed().Write(&bytes.Buffer{}, "json"))
+}
+
+func TestBuild(t *testing.T) {
+	m := &pocketmock.MockClient{
+		RetrieveFunc: func(ctx context.Context, rd *pocket.RetrieveInput) (*pocket.RetrieveResponse, error) {
+			return &pocket.RetrieveResponse{
+				Since: 1700000000,
+				List: map[string]pocket.RetrieveListItem{
+					"1": {SortID: 1},
+					"2": {SortID: 0},
+				},
+			}, nil
+		},
+	}
+
+	f, err := Build(context.Background(), m, pocket.RetrieveInput{Tag: "share"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1700000000), f.Since)
+	require.Equal(t, "2", f.Items[0].ItemID)
+	require.Equal(t, "1", f.Items[1].ItemID)
+
+	rd := m.RetrieveCalls()[0].Rd
+	require.Equal(t, "share", rd.Tag)
+	require.Equal(t, pocket.Complete, rd.DetailType)
+	require.Equal(t, pocket.Newest, rd.Sort)
+	require.Equal(t, int64(defaultLimit), rd.Count)
+}
@@ -0,0 +1,197 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+// defaultTTL is how long a feed is served without checking Pocket for changes.
+const defaultTTL = 5 * time.Minute
+
+// Handler serves a feed of items retrieved with an input. The document is cached and Pocket is checked
+// for changes at most once per TTL, with Retrieve and the since cursor of the cached document; the document
+// is rebuilt only if it returns changes. If the check fails, the cached document is served until the next one.
+// The cursor is used as the ETag and Last-Modified, so unchanged feeds are answered with 304.
+type Handler struct {
+	client pocket.Client
+	input  pocket.RetrieveInput
+	format Format
+	meta   Feed
+	ttl    time.Duration
+	now    func() time.Time
+
+	// refreshMu allows one check of Pocket at a time, requests coming meanwhile get the cached document
+	refreshMu  sync.Mutex
+	mu         sync.Mutex
+	doc        *cachedFeed
+	checked    time.Time
+	refreshing bool
+}
+
+type cachedFeed struct {
+	body     []byte
+	since    int64
+	modified time.Time
+}
+
+func NewHandler(c pocket.Client, in pocket.RetrieveInput, format Format) *Handler {
+	return &Handler{
+		client: c,
+		input:  in,
+		format: format,
+		ttl:    defaultTTL,
+		now:    time.Now,
+	}
+}
+
+func (h *Handler) WithTitle(title string) *Handler {
+	h.meta.Title = title
+	return h
+}
+
+func (h *Handler) WithLink(link string) *Handler {
+	h.meta.Link = link
+	return h
+}
+
+func (h *Handler) WithDescription(description string) *Handler {
+	h.meta.Description = description
+	return h
+}
+
+func (h *Handler) WithAuthor(author string) *Handler {
+	h.meta.Author = author
+	return h
+}
+
+// WithTTL sets how long the feed is served without checking Pocket for changes, 5 minutes by default.
+// Every check is a Retrieve call counted in the rate limit.
+func (h *Handler) WithTTL(ttl time.Duration) *Handler {
+	h.ttl = ttl
+	return h
+}
+
+// ByFavorited dates entries with the time items were favorited, e.g. for a feed of favorites.
+func (h *Handler) ByFavorited() *Handler {
+	h.meta.ByFavorited = true
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	doc, err := h.current(r.Context())
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", h.format.ContentType())
+	w.Header().Set("ETag", `"`+strconv.FormatInt(doc.since, 10)+`"`)
+	http.ServeContent(w, r, "", doc.modified, bytes.NewReader(doc.body))
+}
+
+// current returns the cached document, checking Pocket for changes once the TTL is over.
+func (h *Handler) current(ctx context.Context) (*cachedFeed, error) {
+	if doc, ok := h.fresh(true); ok {
+		return doc, nil
+	}
+
+	h.refreshMu.Lock()
+	defer h.refreshMu.Unlock()
+
+	// the document may have been refreshed while waiting
+	if doc, ok := h.fresh(false); ok {
+		return doc, nil
+	}
+
+	h.mu.Lock()
+	prev := h.doc
+	h.refreshing = true
+	h.mu.Unlock()
+
+	doc, err := h.refresh(ctx, prev)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.refreshing = false
+	h.checked = h.now()
+	if err != nil {
+		if prev != nil {
+			return prev, nil
+		}
+		return nil, err
+	}
+	h.doc = doc
+	return doc, nil
+}
+
+// fresh returns the cached document if it was checked within the TTL or, with whileRefreshing,
+// if another request is checking it now.
+func (h *Handler) fresh(whileRefreshing bool) (*cachedFeed, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.doc == nil {
+		return nil, false
+	}
+	if (whileRefreshing && h.refreshing) || h.now().Sub(h.checked) < h.ttl {
+		return h.doc, true
+	}
+	return nil, false
+}
+
+// refresh returns prev if the list didn't change since it was built, otherwise it builds the document.
+func (h *Handler) refresh(ctx context.Context, prev *cachedFeed) (*cachedFeed, error) {
+	if prev != nil {
+		since := prev.since
+		res, err := h.client.Retrieve(ctx, &pocket.RetrieveInput{
+			State:      pocket.All,
+			Since:      &since,
+			Count:      1,
+			DetailType: pocket.Simple,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error while checking feed changes: %w", err)
+		}
+		if len(res.List) == 0 {
+			return prev, nil
+		}
+	}
+
+	f, err := Build(ctx, h.client, h.input)
+	if err != nil {
+		return nil, err
+	}
+	f.Title = h.meta.Title
+	f.Link = h.meta.Link
+	f.Description = h.meta.Description
+	f.Author = h.meta.Author
+	f.ByFavorited = h.meta.ByFavorited
+
+	buf := bytes.Buffer{}
+	if err := f.Write(&buf, h.format); err != nil {
+		return nil, err
+	}
+
+	doc := &cachedFeed{
+		body:     buf.Bytes(),
+		since:    f.Since,
+		modified: time.Unix(f.Since, 0),
+	}
+	if f.Since == 0 {
+		doc.modified = h.now()
+	}
+	return doc, nil
+}
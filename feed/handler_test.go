@@ -0,0 +1,158 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/pocketmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	const cursor = 1700000000
+	since := cursor
+	changed := false
+
+	m := &pocketmock.MockClient{
+		RetrieveFunc: func(ctx context.Context, rd *pocket.RetrieveInput) (*pocket.RetrieveResponse, error) {
+			if rd.Since != nil {
+				require.Equal(t, int64(cursor), *rd.Since)
+				list := map[string]pocket.RetrieveListItem{}
+				if changed {
+					list["2"] = pocket.RetrieveListItem{ItemID: "2"}
+				}
+				return &pocket.RetrieveResponse{Since: since, List: list}, nil
+			}
+
+			require.Equal(t, "share", rd.Tag)
+			return &pocket.RetrieveResponse{
+				Since: since,
+				List: map[string]pocket.RetrieveListItem{
+					"1": {ItemID: "1", GivenURL: "https://go.dev", TimeAdded: "1600000000"},
+				},
+			}, nil
+		},
+	}
+
+	clock := time.Unix(cursor, 0)
+	h := NewHandler(m, pocket.RetrieveInput{Tag: "share"}, RSS).WithTitle("Shared").WithTTL(time.Minute)
+	h.now = func() time.Time { return clock }
+
+	get := func(etag string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/feed", nil)
+		if etag != "" {
+			r.Header.Set("If-None-Match", etag)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := get("")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, RSS.ContentType(), w.Header().Get("Content-Type"))
+	require.Equal(t, `"1700000000"`, w.Header().Get("ETag"))
+	require.Equal(t, "Tue, 14 Nov 2023 22:13:20 GMT", w.Header().Get("Last-Modified"))
+	require.True(t, strings.Contains(w.Body.String(), "<title>Shared</title>"))
+
+	// within the TTL Pocket isn't checked
+	w = get(`"1700000000"`)
+	require.Equal(t, http.StatusNotModified, w.Code)
+	require.Len(t, m.RetrieveCalls(), 1)
+
+	clock = clock.Add(time.Minute)
+	w = get(`"1700000000"`)
+	require.Equal(t, http.StatusNotModified, w.Code)
+	require.Len(t, m.RetrieveCalls(), 2)
+
+	r := httptest.NewRequest(http.MethodGet, "/feed", nil)
+	r.Header.Set("If-Modified-Since", "Tue, 14 Nov 2023 22:13:20 GMT")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusNotModified, w.Code)
+
+	require.Len(t, m.RetrieveCalls(), 2)
+
+	changed = true
+	since += 10
+	w = get(`"1700000000"`)
+	require.Equal(t, http.StatusNotModified, w.Code)
+
+	clock = clock.Add(time.Minute)
+	w = get(`"1700000000"`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `"1700000010"`, w.Header().Get("ETag"))
+	require.Len(t, m.RetrieveCalls(), 4)
+}
+
+func TestHandler_Stale(t *testing.T) {
+	down := false
+	m := &pocketmock.MockClient{
+		RetrieveFunc: func(ctx context.Context, rd *pocket.RetrieveInput) (*pocket.RetrieveResponse, error) {
+			if down {
+				return nil, errors.New("pocket is down")
+			}
+			return &pocket.RetrieveResponse{
+				Since: 1700000000,
+				List: map[string]pocket.RetrieveListItem{
+					"1": {ItemID: "1", GivenURL: "https://go.dev", TimeAdded: "1600000000"},
+				},
+			}, nil
+		},
+	}
+
+	clock := time.Unix(1700000000, 0)
+	h := NewHandler(m, pocket.RetrieveInput{}, Atom)
+	h.now = func() time.Time { return clock }
+
+	get := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/feed", nil))
+		return w
+	}
+
+	w := get()
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+
+	down = true
+	clock = clock.Add(defaultTTL)
+	w = get()
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, body, w.Body.String())
+	require.Len(t, m.RetrieveCalls(), 2)
+
+	// the failed check counts, Pocket is checked again after the TTL
+	w = get()
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, m.RetrieveCalls(), 2)
+}
+
+func TestHandler_Errors(t *testing.T) {
+	m := &pocketmock.MockClient{
+		RetrieveFunc: func(ctx context.Context, rd *pocket.RetrieveInput) (*pocket.RetrieveResponse, error) {
+			return nil, errors.New("pocket is down")
+		},
+	}
+	h := NewHandler(m, pocket.RetrieveInput{}, Atom)
+
+	tests := []struct {
+		method string
+		code   int
+	}{
+		{http.MethodPost, http.StatusMethodNotAllowed},
+		{http.MethodGet, http.StatusBadGateway},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(test.method, "/feed", nil))
+		require.Equal(t, test.code, w.Code, test.method)
+	}
+}
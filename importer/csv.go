@@ -0,0 +1,101 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VladimirStepanov/pocket-golang-sdk/export"
+)
+
+type CSVOptions struct {
+	TagSeparator string // "," if empty
+	TimeFormat   string // layout of time.Parse, unix seconds if empty
+}
+
+// ParseCSV reads CSV with a header row naming the columns, as written by export.CSV.
+// The url column is required, the url, title, tags, status, favorite and time_added
+// columns are used and others are ignored.
+func ParseCSV(r io.Reader, opts CSVOptions) ([]Bookmark, error) {
+	sep := opts.TagSeparator
+	if sep == "" {
+		sep = ","
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error while reading csv header: %w", err)
+	}
+	columns := map[export.Column]int{}
+	for i, name := range header {
+		columns[export.Column(strings.ToLower(strings.TrimSpace(name)))] = i
+	}
+	if _, ok := columns[export.ColumnURL]; !ok {
+		return nil, fmt.Errorf("csv has no %s column", export.ColumnURL)
+	}
+
+	var bookmarks []Bookmark
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return bookmarks, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error while reading csv record: %w", err)
+		}
+
+		field := func(c export.Column) string {
+			i, ok := columns[c]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		b := Bookmark{
+			URL:      field(export.ColumnURL),
+			Title:    field(export.ColumnTitle),
+			Archived: field(export.ColumnStatus) == export.StatusArchived,
+		}
+		if b.URL == "" {
+			continue
+		}
+		for _, tag := range strings.Split(field(export.ColumnTags), sep) {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				b.Tags = append(b.Tags, tag)
+			}
+		}
+		if v := field(export.ColumnFavorite); v != "" {
+			b.Favorite, err = strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("error while parsing favorite on line %d: %w", line, err)
+			}
+		}
+		if v := field(export.ColumnTimeAdded); v != "" {
+			b.Added, err = parseTime(v, opts.TimeFormat)
+			if err != nil {
+				return nil, fmt.Errorf("error while parsing time_added on line %d: %w", line, err)
+			}
+		}
+
+		bookmarks = append(bookmarks, b)
+	}
+}
+
+func parseTime(v string, layout string) (time.Time, error) {
+	if layout != "" {
+		return time.Parse(layout, v)
+	}
+	sec, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(sec, 0), nil
+}
@@ -0,0 +1,99 @@
+package importer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/export"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		opts    CSVOptions
+		want    []Bookmark
+		wantErr bool
+	}{
+		{
+			name: "columns in any order",
+			src: `Title,URL,tags,favorite,extra
+Go,https://go.dev,"go, lang",true,x
+,https://example.com,,,
+,,skipped,,
+`,
+			want: []Bookmark{
+				{URL: "https://go.dev", Title: "Go", Tags: []string{"go", "lang"}, Favorite: true},
+				{URL: "https://example.com"},
+			},
+		},
+		{
+			name: "options",
+			src: `url,tags,status,time_added
+https://go.dev,go|lang,archived,2020-09-13
+`,
+			opts: CSVOptions{TagSeparator: "|", TimeFormat: "2006-01-02"},
+			want: []Bookmark{
+				{
+					URL:      "https://go.dev",
+					Tags:     []string{"go", "lang"},
+					Added:    time.Date(2020, 9, 13, 0, 0, 0, 0, time.UTC),
+					Archived: true,
+				},
+			},
+		},
+		{
+			name:    "no url column",
+			src:     "title\nGo\n",
+			wantErr: true,
+		},
+		{
+			name:    "bad time",
+			src:     "url,time_added\nhttps://go.dev,yesterday\n",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bookmarks, err := ParseCSV(strings.NewReader(test.src), test.opts)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.want, bookmarks)
+		})
+	}
+}
+
+func TestParseCSV_Export(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, export.CSV(buf, []pocket.RetrieveListItem{
+		{
+			Status:     pocket.ItemStatusArchived,
+			GivenURL:   "https://go.dev",
+			GivenTitle: "Go, the language",
+			Favorite:   "1",
+			TimeAdded:  "1600000000",
+			Tags:       map[string]pocket.Tag{"go": {Tag: "go"}, "lang": {Tag: "lang"}},
+		},
+	}, export.CSVOptions{}))
+
+	bookmarks, err := ParseCSV(buf, CSVOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []Bookmark{
+		{
+			URL:      "https://go.dev",
+			Title:    "Go, the language",
+			Tags:     []string{"go", "lang"},
+			Added:    time.Unix(1600000000, 0),
+			Archived: true,
+			Favorite: true,
+		},
+	}, bookmarks)
+}
@@ -0,0 +1,294 @@
+// Package importer adds links from bookmark files to Pocket.
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+const defaultChunkSize = 100
+
+// Progress is called after every Modify chunk.
+type Progress func(done, total int)
+
+type Result struct {
+	Duplicates int // bookmarks skipped because they are already saved or repeat in the file
+	Resumed    int // bookmarks skipped because the progress file has them
+	Planned    pocket.Actions
+	Responses  []*pocket.ModifyResponse
+	Done       int // number of sent actions
+}
+
+// Importer turns bookmarks into add actions and sends them with Modify.
+type Importer struct {
+	ctx          context.Context
+	client       pocket.Client
+	chunkSize    int
+	dedupe       bool
+	progressPath string
+	progress     Progress
+}
+
+func New(ctx context.Context, c pocket.Client) *Importer {
+	return &Importer{
+		ctx:       ctx,
+		client:    c,
+		chunkSize: defaultChunkSize,
+		dedupe:    true,
+	}
+}
+
+// WithChunkSize sets the number of actions sent with one Modify call.
+func (im *Importer) WithChunkSize(n int) *Importer {
+	if n > 0 {
+		im.chunkSize = n
+	}
+	return im
+}
+
+// WithoutDedupe makes the importer add bookmarks without retrieving the saved items first.
+func (im *Importer) WithoutDedupe() *Importer {
+	im.dedupe = false
+	return im
+}
+
+// WithProgressFile makes the importer record urls of sent chunks and archive and favorite actions
+// not sent yet in the file. An import of the same bookmarks started again after a failure skips the urls
+// and sends the actions.
+// The file is removed when the import completes.
+func (im *Importer) WithProgressFile(path string) *Importer {
+	im.progressPath = path
+	return im
+}
+
+func (im *Importer) WithProgress(fn Progress) *Importer {
+	im.progress = fn
+	return im
+}
+
+// Import adds the bookmarks. Bookmarks whose normalized url is already saved are skipped.
+func (im *Importer) Import(bookmarks []Bookmark) (*Result, error) {
+	res := &Result{}
+	done, pending, err := im.loadProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	// archive and favorite actions of items added by an import which failed before sending them
+	if len(pending) != 0 {
+		if err := im.sendFollowUp(pending); err != nil {
+			return res, err
+		}
+		if err := im.saveProgress(done, nil); err != nil {
+			return res, err
+		}
+	}
+
+	seen := map[string]bool{}
+	if im.dedupe {
+		items, err := pocket.RetrieveAll(im.ctx, im.client, &pocket.RetrieveInput{State: pocket.All})
+		if err != nil {
+			return res, fmt.Errorf("error while retrieving saved items: %w", err)
+		}
+		for _, item := range items {
+			seen[pocket.NormalizeURL(item.GivenURL)] = true
+			seen[pocket.NormalizeURL(item.ResolvedURL)] = true
+		}
+	}
+
+	var (
+		urls    []string
+		planned []*Bookmark
+	)
+	for i := range bookmarks {
+		b := &bookmarks[i]
+		key := pocket.NormalizeURL(b.URL)
+		switch {
+		case done[key]:
+			res.Resumed++
+			continue
+		case seen[key]:
+			res.Duplicates++
+			continue
+		}
+		seen[key] = true
+
+		a := &pocket.ActionAdd{
+			Action: pocket.ActionAddType,
+			Url:    b.URL,
+			Title:  b.Title,
+			Tags:   strings.Join(b.Tags, ","),
+		}
+		if !b.Added.IsZero() {
+			a.Time = b.Added.Unix()
+		}
+		res.Planned = append(res.Planned, a)
+		urls = append(urls, key)
+		planned = append(planned, b)
+	}
+
+	for start := 0; start < len(res.Planned); start += im.chunkSize {
+		end := start + im.chunkSize
+		if end > len(res.Planned) {
+			end = len(res.Planned)
+		}
+
+		resp, err := im.client.Modify(im.ctx, res.Planned[start:end])
+		if err != nil {
+			return res, err
+		}
+		res.Responses = append(res.Responses, resp)
+		res.Done = end
+
+		// the follow-up is saved with the added urls before it is sent,
+		// so an import started again after a failure sends it instead of skipping the items as duplicates
+		for _, key := range urls[start:end] {
+			done[key] = true
+		}
+		followUp := followUpActions(resp, planned[start:end])
+		if err := im.saveProgress(done, followUp); err != nil {
+			return res, err
+		}
+		if len(followUp) != 0 {
+			if err := im.sendFollowUp(followUp); err != nil {
+				return res, err
+			}
+			if err := im.saveProgress(done, nil); err != nil {
+				return res, err
+			}
+		}
+
+		if im.progress != nil {
+			im.progress(res.Done, len(res.Planned))
+		}
+	}
+
+	if im.progressPath != "" {
+		if err := os.Remove(im.progressPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return res, fmt.Errorf("error while removing progress file: %w", err)
+		}
+	}
+
+	return res, nil
+}
+
+// followUpActions returns actions archiving and favoriting added items of the bookmarks,
+// the add action itself always saves items as unread and not favorite.
+func followUpActions(resp *pocket.ModifyResponse, bookmarks []*Bookmark) []pocket.ActionFields {
+	now := time.Now().Unix()
+
+	var actions []pocket.ActionFields
+	for i, r := range resp.ActionResult {
+		if i >= len(bookmarks) || !bookmarks[i].Archived && !bookmarks[i].Favorite {
+			continue
+		}
+		item, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		s, _ := item["item_id"].(string)
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			continue
+		}
+		if bookmarks[i].Archived {
+			actions = append(actions, pocket.ActionFields{Action: pocket.ActionArchiveType, ItemID: id, Time: now})
+		}
+		if bookmarks[i].Favorite {
+			actions = append(actions, pocket.ActionFields{Action: pocket.ActionFavoriteType, ItemID: id, Time: now})
+		}
+	}
+	return actions
+}
+
+func (im *Importer) sendFollowUp(fields []pocket.ActionFields) error {
+	actions := make(pocket.Actions, 0, len(fields))
+	for _, f := range fields {
+		a, err := f.Typed()
+		if err != nil {
+			return err
+		}
+		actions = append(actions, a)
+	}
+
+	_, err := im.client.Modify(im.ctx, actions)
+	if err != nil {
+		return fmt.Errorf("error while updating imported items: %w", err)
+	}
+	return nil
+}
+
+type progressFile struct {
+	Done []string `json:"done"`
+	// archive and favorite actions of added items not sent yet
+	Pending []pocket.ActionFields `json:"pending,omitempty"`
+}
+
+func (im *Importer) loadProgress() (map[string]bool, []pocket.ActionFields, error) {
+	done := map[string]bool{}
+	if im.progressPath == "" {
+		return done, nil, nil
+	}
+
+	data, err := os.ReadFile(im.progressPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return done, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while reading progress file: %w", err)
+	}
+
+	pf := progressFile{}
+	if err := json.Unmarshal(data, &pf); err != nil {
+		return nil, nil, fmt.Errorf("error while unmarshalling progress file: %w", err)
+	}
+	for _, key := range pf.Done {
+		done[key] = true
+	}
+	return done, pf.Pending, nil
+}
+
+func (im *Importer) saveProgress(done map[string]bool, pending []pocket.ActionFields) error {
+	if im.progressPath == "" {
+		return nil
+	}
+
+	pf := progressFile{Done: make([]string, 0, len(done)), Pending: pending}
+	for key := range done {
+		pf.Done = append(pf.Done, key)
+	}
+	sort.Strings(pf.Done)
+	data, err := json.Marshal(&pf)
+	if err != nil {
+		return fmt.Errorf("error while marshalling progress file: %w", err)
+	}
+
+	// write a temp file and rename it, so a crash never leaves a broken progress file
+	tmp, err := os.CreateTemp(filepath.Dir(im.progressPath), filepath.Base(im.progressPath)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("error while creating temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error while writing progress file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error while closing progress file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), im.progressPath); err != nil {
+		return fmt.Errorf("error while replacing progress file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/pocketmock"
+	"github.com/stretchr/testify/require"
+)
+
+func saved(urls ...string) func(ctx context.Context, rd *pocket.RetrieveInput) (*pocket.RetrieveResponse, error) {
+	return func(ctx context.Context, rd *pocket.RetrieveInput) (*pocket.RetrieveResponse, error) {
+		list := map[string]pocket.RetrieveListItem{}
+		for i, u := range urls {
+			list[u] = pocket.RetrieveListItem{SortID: i, GivenURL: u}
+		}
+		return &pocket.RetrieveResponse{Status: 1, List: list}, nil
+	}
+}
+
+func TestImporter_Import(t *testing.T) {
+	m := &pocketmock.MockClient{
+		RetrieveFunc: saved("http://www.example.com/saved/"),
+		ModifyFunc: func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+			return &pocket.ModifyResponse{Status: 1}, nil
+		},
+	}
+
+	var progress []int
+	res, err := New(context.Background(), m).
+		WithChunkSize(2).
+		WithProgress(func(done, total int) { progress = append(progress, done) }).
+		Import([]Bookmark{
+			{URL: "https://example.com/a", Title: "A", Tags: []string{"x", "y"}, Added: time.Unix(1600000000, 0)},
+			{URL: "https://example.com/saved"},
+			{URL: "https://example.com/b"},
+			{URL: "http://example.com/a/"},
+			{URL: "https://example.com/c"},
+		})
+	require.NoError(t, err)
+
+	require.Equal(t, 2, res.Duplicates)
+	require.Equal(t, 3, res.Done)
+	require.Equal(t, []int{2, 3}, progress)
+	require.Equal(t, &pocket.ActionAdd{
+		Action: pocket.ActionAddType,
+		Url:    "https://example.com/a",
+		Title:  "A",
+		Tags:   "x,y",
+		Time:   1600000000,
+	}, res.Planned[0])
+
+	calls := m.ModifyCalls()
+	require.Len(t, calls, 2)
+	require.Len(t, calls[0].Actions, 2)
+	require.Len(t, calls[1].Actions, 1)
+}
+
+func TestImporter_FollowUp(t *testing.T) {
+	m := &pocketmock.MockClient{
+		ModifyFunc: func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+			res := &pocket.ModifyResponse{Status: 1}
+			for i := range actions {
+				res.ActionResult = append(res.ActionResult, map[string]interface{}{"item_id": fmt.Sprint(i + 10)})
+			}
+			return res, nil
+		},
+	}
+
+	_, err := New(context.Background(), m).WithoutDedupe().Import([]Bookmark{
+		{URL: "https://example.com/a"},
+		{URL: "https://example.com/b", Archived: true, Favorite: true},
+	})
+	require.NoError(t, err)
+
+	calls := m.ModifyCalls()
+	require.Len(t, calls, 2)
+	require.Len(t, calls[1].Actions, 2)
+	for i, want := range []pocket.ActionType{pocket.ActionArchiveType, pocket.ActionFavoriteType} {
+		a, err := pocket.ParseAction(calls[1].Actions[i])
+		require.NoError(t, err)
+		require.Equal(t, want, a.Action)
+		require.Equal(t, int64(11), a.ItemID)
+	}
+}
+
+func TestImporter_Resume(t *testing.T) {
+	progressPath := filepath.Join(t.TempDir(), "import.json")
+	bookmarks := []Bookmark{
+		{URL: "https://example.com/a"},
+		{URL: "https://example.com/b"},
+		{URL: "https://example.com/c"},
+	}
+
+	failAt := 2
+	m := &pocketmock.MockClient{
+		ModifyFunc: func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+			failAt--
+			if failAt == 0 {
+				return nil, errors.New("network is down")
+			}
+			return &pocket.ModifyResponse{Status: 1}, nil
+		},
+	}
+
+	im := New(context.Background(), m).WithoutDedupe().WithChunkSize(1).WithProgressFile(progressPath)
+	res, err := im.Import(bookmarks)
+	require.Error(t, err)
+	require.Equal(t, 1, res.Done)
+
+	data, err := os.ReadFile(progressPath)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"done":["https://example.com/a"]}`, string(data))
+
+	res, err = im.Import(bookmarks)
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Resumed)
+	require.Equal(t, 2, res.Done)
+	require.Empty(t, m.RetrieveCalls())
+
+	_, err = os.Stat(progressPath)
+	require.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestImporter_ResumeFollowUp(t *testing.T) {
+	progressPath := filepath.Join(t.TempDir(), "import.json")
+	bookmarks := []Bookmark{
+		{URL: "https://example.com/a", Archived: true},
+		{URL: "https://example.com/b"},
+	}
+
+	followUpFails := true
+	m := &pocketmock.MockClient{
+		ModifyFunc: func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+			if _, ok := actions[0].(*pocket.ActionAdd); !ok && followUpFails {
+				return nil, errors.New("network is down")
+			}
+			res := &pocket.ModifyResponse{Status: 1}
+			for i := range actions {
+				res.ActionResult = append(res.ActionResult, map[string]interface{}{"item_id": fmt.Sprint(i + 10)})
+			}
+			return res, nil
+		},
+	}
+
+	im := New(context.Background(), m).WithoutDedupe().WithProgressFile(progressPath)
+	res, err := im.Import(bookmarks)
+	require.Error(t, err)
+	require.Equal(t, 2, res.Done)
+
+	// the items are added, the archive action is kept for the next run
+	pf := progressFile{}
+	data, err := os.ReadFile(progressPath)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &pf))
+	require.Equal(t, []string{"https://example.com/a", "https://example.com/b"}, pf.Done)
+	require.Len(t, pf.Pending, 1)
+	require.Equal(t, pocket.ActionArchiveType, pf.Pending[0].Action)
+	require.Equal(t, int64(10), pf.Pending[0].ItemID)
+
+	followUpFails = false
+	res, err = im.Import(bookmarks)
+	require.NoError(t, err)
+	require.Equal(t, 2, res.Resumed)
+	require.Equal(t, 0, res.Done)
+
+	calls := m.ModifyCalls()
+	require.Len(t, calls, 3)
+	require.Equal(t, pocket.Actions{&pocket.ActionArchive{
+		Action: pocket.ActionArchiveType,
+		ItemID: 10,
+		Time:   pf.Pending[0].Time,
+	}}, calls[2].Actions)
+
+	_, err = os.Stat(progressPath)
+	require.True(t, errors.Is(err, os.ErrNotExist))
+}
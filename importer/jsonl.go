@@ -0,0 +1,65 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+const maxJSONLineSize = 16 << 20
+
+// ReadJSONLines reads items written by export.JSONLines. Empty lines are skipped.
+func ReadJSONLines(r io.Reader) ([]pocket.RetrieveListItem, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), maxJSONLineSize)
+
+	var items []pocket.RetrieveListItem
+	for line := 1; sc.Scan(); line++ {
+		if strings.TrimSpace(sc.Text()) == "" {
+			continue
+		}
+		item := pocket.RetrieveListItem{}
+		if err := json.Unmarshal(sc.Bytes(), &item); err != nil {
+			return nil, fmt.Errorf("error while unmarshalling item on line %d: %w", line, err)
+		}
+		items = append(items, item)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading items: %w", err)
+	}
+	return items, nil
+}
+
+// FromItems returns bookmarks adding the items again with their tags, time, status and favorite.
+// Deleted items are skipped.
+func FromItems(items []pocket.RetrieveListItem) []Bookmark {
+	bookmarks := make([]Bookmark, 0, len(items))
+	for _, item := range items {
+		if item.Status == pocket.ItemStatusDeleted {
+			continue
+		}
+
+		b := Bookmark{
+			URL:      item.GivenURL,
+			Title:    item.GivenTitle,
+			Added:    item.TimeAddedAt(),
+			Archived: item.Status == pocket.ItemStatusArchived,
+			Favorite: item.IsFavorite(),
+		}
+		if tags := item.TagNames(); len(tags) != 0 {
+			b.Tags = tags
+		}
+		if b.URL == "" {
+			b.URL = item.ResolvedURL
+		}
+		if b.URL == "" {
+			continue
+		}
+		bookmarks = append(bookmarks, b)
+	}
+	return bookmarks
+}
@@ -0,0 +1,52 @@
+package importer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/export"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadJSONLines(t *testing.T) {
+	items := []pocket.RetrieveListItem{
+		{
+			ItemID:      "1",
+			GivenURL:    "https://go.dev",
+			Status:      pocket.ItemStatusArchived,
+			Favorite:    "1",
+			TimeAdded:   "1600000000",
+			Tags:        map[string]pocket.Tag{"go": {ItemID: "1", Tag: "go"}},
+			Annotations: []pocket.Annotation{{AnnotationID: "a", ItemID: "1", Quote: "simple", Version: "2"}},
+		},
+		{ItemID: "2", ResolvedURL: "https://example.com", GivenTitle: "Example"},
+		{ItemID: "3", GivenURL: "https://deleted.com", Status: pocket.ItemStatusDeleted},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, export.JSONLines(buf, items))
+	buf.WriteString("\n")
+
+	read, err := ReadJSONLines(buf)
+	require.NoError(t, err)
+	require.Equal(t, items, read)
+
+	require.Equal(t, []Bookmark{
+		{
+			URL:      "https://go.dev",
+			Tags:     []string{"go"},
+			Added:    time.Unix(1600000000, 0),
+			Archived: true,
+			Favorite: true,
+		},
+		{URL: "https://example.com", Title: "Example"},
+	}, FromItems(read))
+}
+
+func TestReadJSONLines_Broken(t *testing.T) {
+	_, err := ReadJSONLines(strings.NewReader("{\"item_id\":\"1\"}\n{\"item_id\":"))
+	require.Error(t, err)
+}
@@ -0,0 +1,146 @@
+package importer
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// folder names used by Pocket in its export, they are not turned into tags
+const (
+	UnreadFolder  = "Unread"
+	ArchiveFolder = "Read Archive"
+)
+
+var (
+	tagRe  = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)([^>]*)>`)
+	attrRe = regexp.MustCompile(`([a-zA-Z_:-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+)
+
+// Bookmark is a link read from an import file.
+type Bookmark struct {
+	URL      string
+	Title    string
+	Tags     []string
+	Added    time.Time // zero if the file has no date
+	Archived bool      // archived after it is added, e.g. the link is in the Read Archive folder
+	Favorite bool      // favorited after it is added
+}
+
+// ParseHTML reads Netscape bookmark HTML as exported by browsers and Pocket's ril_export.html.
+// Names of the folders a link is in and its TAGS attribute become tags.
+func ParseHTML(r io.Reader) ([]Bookmark, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading bookmarks: %w", err)
+	}
+	src := string(data)
+
+	var (
+		bookmarks []Bookmark
+		folders   []string // folders of the open <DL> lists
+		heading   string   // the last <H3>, it names the next <DL>
+		section   string   // the last <H1>, Pocket's export uses it for the status
+		open      *element
+	)
+
+	for _, m := range tagRe.FindAllStringSubmatchIndex(src, -1) {
+		closing := m[3] > m[2]
+		name := strings.ToLower(src[m[4]:m[5]])
+
+		if open != nil {
+			if !closing || name != open.name {
+				continue
+			}
+			text := strings.TrimSpace(html.UnescapeString(tagRe.ReplaceAllString(src[open.textStart:m[0]], "")))
+
+			switch open.name {
+			case "h1":
+				section = text
+			case "h3":
+				heading = text
+			case "a":
+				if b, ok := newBookmark(open.attrs, text, section, folders); ok {
+					bookmarks = append(bookmarks, b)
+				}
+			}
+			open = nil
+			continue
+		}
+
+		switch {
+		case !closing && (name == "h1" || name == "h3" || name == "a"):
+			open = &element{name: name, attrs: parseAttrs(src[m[6]:m[7]]), textStart: m[1]}
+		case !closing && name == "dl":
+			folders = append(folders, heading)
+			heading = ""
+		case closing && name == "dl" && len(folders) != 0:
+			folders = folders[:len(folders)-1]
+		}
+	}
+
+	return bookmarks, nil
+}
+
+type element struct {
+	name      string
+	attrs     map[string]string
+	textStart int
+}
+
+func parseAttrs(s string) map[string]string {
+	attrs := map[string]string{}
+	for _, m := range attrRe.FindAllStringSubmatch(s, -1) {
+		attrs[strings.ToLower(m[1])] = html.UnescapeString(m[2] + m[3] + m[4])
+	}
+	return attrs
+}
+
+func newBookmark(attrs map[string]string, title string, section string, folders []string) (Bookmark, bool) {
+	b := Bookmark{
+		URL:      strings.TrimSpace(attrs["href"]),
+		Title:    title,
+		Archived: section == ArchiveFolder,
+	}
+	if b.URL == "" || strings.HasPrefix(b.URL, "javascript:") || strings.HasPrefix(b.URL, "place:") {
+		return b, false
+	}
+	if b.Title == b.URL {
+		b.Title = ""
+	}
+
+	added := attrs["add_date"]
+	if added == "" {
+		added = attrs["time_added"]
+	}
+	if sec, err := strconv.ParseInt(added, 10, 64); err == nil && sec > 0 {
+		b.Added = time.Unix(sec, 0)
+	}
+
+	seen := map[string]bool{}
+	addTag := func(tag string) {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			b.Tags = append(b.Tags, tag)
+		}
+	}
+	for _, folder := range folders {
+		switch folder {
+		case ArchiveFolder:
+			b.Archived = true
+		case UnreadFolder, "":
+		default:
+			addTag(folder)
+		}
+	}
+	for _, tag := range strings.Split(attrs["tags"], ",") {
+		addTag(tag)
+	}
+
+	return b, true
+}
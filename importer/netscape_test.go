@@ -0,0 +1,107 @@
+package importer
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/export"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []Bookmark
+	}{
+		{
+			name: "browser",
+			src: `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+<DL><p>
+    <DT><A HREF="https://go.dev/" ADD_DATE="1600000000" TAGS="go,lang">Go &amp; more</A>
+    <DT><H3 ADD_DATE="1500000000">Dev</H3>
+    <DL><p>
+        <DT><H3>Tools</H3>
+        <DL><p>
+            <DT><A HREF="https://example.com/tool" TAGS="Dev">https://example.com/tool</A>
+        </DL><p>
+        <DT><A HREF='https://example.com/doc'>Doc</A>
+        <DT><A HREF="javascript:alert(1)">Bookmarklet</A>
+    </DL><p>
+    <DT><A HREF="https://example.com/top">Top</A>
+</DL><p>`,
+			want: []Bookmark{
+				{URL: "https://go.dev/", Title: "Go & more", Tags: []string{"go", "lang"}, Added: time.Unix(1600000000, 0)},
+				{URL: "https://example.com/tool", Tags: []string{"Dev", "Tools"}},
+				{URL: "https://example.com/doc", Title: "Doc", Tags: []string{"Dev"}},
+				{URL: "https://example.com/top", Title: "Top"},
+			},
+		},
+		{
+			name: "pocket",
+			src: `<!DOCTYPE html>
+<html><head><title>Pocket Export</title></head><body>
+<h1>Unread</h1>
+<ul>
+<li><a href="https://go.dev" time_added="1600000000" tags="go">Go</a></li>
+</ul>
+<h1>Read Archive</h1>
+<ul>
+<li><a href="https://example.com" time_added="1500000000" tags="">Example</a></li>
+</ul>
+</body></html>`,
+			want: []Bookmark{
+				{URL: "https://go.dev", Title: "Go", Tags: []string{"go"}, Added: time.Unix(1600000000, 0)},
+				{URL: "https://example.com", Title: "Example", Added: time.Unix(1500000000, 0), Archived: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bookmarks, err := ParseHTML(strings.NewReader(test.src))
+			require.NoError(t, err)
+			require.Equal(t, test.want, bookmarks)
+		})
+	}
+}
+
+func TestParseHTML_Export(t *testing.T) {
+	items := []pocket.RetrieveListItem{
+		{
+			Status:        pocket.ItemStatusUnread,
+			GivenURL:      "https://go.dev/?a=1&b=2",
+			ResolvedTitle: "Go <Home>",
+			TimeAdded:     "1600000000",
+			Tags:          map[string]pocket.Tag{"go": {Tag: "go"}},
+		},
+		{
+			Status:      pocket.ItemStatusArchived,
+			ResolvedURL: "https://example.com",
+			TimeAdded:   "1500000000",
+		},
+	}
+
+	for name, write := range map[string]func(w io.Writer, items []pocket.RetrieveListItem) error{
+		"netscape": export.Netscape,
+		"pocket":   export.PocketHTML,
+	} {
+		t.Run(name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			require.NoError(t, write(buf, items))
+
+			bookmarks, err := ParseHTML(buf)
+			require.NoError(t, err)
+			require.Equal(t, []Bookmark{
+				{URL: "https://go.dev/?a=1&b=2", Title: "Go <Home>", Tags: []string{"go"}, Added: time.Unix(1600000000, 0)},
+				{URL: "https://example.com", Added: time.Unix(1500000000, 0), Archived: true},
+			}, bookmarks)
+		})
+	}
+}
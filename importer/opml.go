@@ -0,0 +1,86 @@
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr"`
+	URL      string        `xml:"url,attr"`
+	HTMLURL  string        `xml:"htmlUrl,attr"`
+	XMLURL   string        `xml:"xmlUrl,attr"`
+	Category string        `xml:"category,attr"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// ParseOPML reads outlines with an url, htmlUrl or xmlUrl attribute, in this order of preference.
+// Texts of the parent outlines and the category attribute become tags, category paths like
+// "/tech/go" give a tag for every part.
+func ParseOPML(r io.Reader) ([]Bookmark, error) {
+	doc := struct {
+		Outlines []opmlOutline `xml:"body>outline"`
+	}{}
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("error while decoding opml: %w", err)
+	}
+
+	var bookmarks []Bookmark
+	var walk func(outlines []opmlOutline, parents []string)
+	walk = func(outlines []opmlOutline, parents []string) {
+		for _, o := range outlines {
+			link := o.URL
+			if link == "" {
+				link = o.HTMLURL
+			}
+			if link == "" {
+				link = o.XMLURL
+			}
+
+			if link != "" {
+				b := Bookmark{URL: strings.TrimSpace(link), Title: strings.TrimSpace(o.Title)}
+				if b.Title == "" {
+					b.Title = strings.TrimSpace(o.Text)
+				}
+				b.Tags = outlineTags(parents, o.Category)
+				bookmarks = append(bookmarks, b)
+			}
+
+			if len(o.Outlines) != 0 {
+				name := strings.TrimSpace(o.Text)
+				if name == "" {
+					name = strings.TrimSpace(o.Title)
+				}
+				walk(o.Outlines, append(parents[:len(parents):len(parents)], name))
+			}
+		}
+	}
+	walk(doc.Outlines, nil)
+
+	return bookmarks, nil
+}
+
+func outlineTags(parents []string, category string) []string {
+	var tags []string
+	seen := map[string]bool{}
+	add := func(tag string) {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	for _, p := range parents {
+		add(p)
+	}
+	for _, c := range strings.Split(category, ",") {
+		for _, part := range strings.Split(c, "/") {
+			add(part)
+		}
+	}
+	return tags
+}
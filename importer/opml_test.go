@@ -0,0 +1,52 @@
+package importer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/export"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOPML(t *testing.T) {
+	src := `<?xml version="1.0"?>
+<opml version="2.0">
+  <head><title>Feeds</title></head>
+  <body>
+    <outline text="Tech">
+      <outline text="Go">
+        <outline text="The Go Blog" type="rss" xmlUrl="https://go.dev/blog/feed.atom" htmlUrl="https://go.dev/blog"/>
+      </outline>
+      <outline text="Verge" type="link" url="https://theverge.com" category="/news/gadgets,daily"/>
+    </outline>
+    <outline text="Feed only" xmlUrl="https://example.com/feed"/>
+    <outline text="No link"/>
+  </body>
+</opml>`
+
+	bookmarks, err := ParseOPML(strings.NewReader(src))
+	require.NoError(t, err)
+	require.Equal(t, []Bookmark{
+		{URL: "https://go.dev/blog", Title: "The Go Blog", Tags: []string{"Tech", "Go"}},
+		{URL: "https://theverge.com", Title: "Verge", Tags: []string{"Tech", "news", "gadgets", "daily"}},
+		{URL: "https://example.com/feed", Title: "Feed only"},
+	}, bookmarks)
+}
+
+func TestParseOPML_Export(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, export.OPML(buf, []pocket.RetrieveListItem{
+		{GivenURL: "https://go.dev/doc", DomainMetadata: pocket.DomainMetadata{Name: "Go"}},
+	}, "Sites"))
+
+	bookmarks, err := ParseOPML(buf)
+	require.NoError(t, err)
+	require.Equal(t, []Bookmark{{URL: "https://go.dev", Title: "Go"}}, bookmarks)
+}
+
+func TestParseOPML_Broken(t *testing.T) {
+	_, err := ParseOPML(strings.NewReader("<opml><body><outline"))
+	require.Error(t, err)
+}
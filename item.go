@@ -0,0 +1,119 @@
+package pocket
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Domain returns the host of the item url without the www. prefix.
+func (i RetrieveListItem) Domain() string {
+	raw := i.ResolvedURL
+	if raw == "" {
+		raw = i.GivenURL
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// TagNames returns sorted tags of the item.
+func (i RetrieveListItem) TagNames() []string {
+	tags := make([]string, 0, len(i.Tags))
+	for tag := range i.Tags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+func (i RetrieveListItem) HasTag(tag string) bool {
+	_, ok := i.Tags[tag]
+	return ok
+}
+
+func (i RetrieveListItem) IsFavorite() bool {
+	return i.Favorite == "1"
+}
+
+func (i RetrieveListItem) Words() int {
+	n, _ := strconv.Atoi(i.WordCount)
+	return n
+}
+
+func (i RetrieveListItem) TimeAddedAt() time.Time {
+	return parseUnix(i.TimeAdded)
+}
+
+func (i RetrieveListItem) TimeUpdatedAt() time.Time {
+	return parseUnix(i.TimeUpdated)
+}
+
+func (i RetrieveListItem) TimeReadAt() time.Time {
+	return parseUnix(i.TimeRead)
+}
+
+func (i RetrieveListItem) TimeFavoritedAt() time.Time {
+	return parseUnix(i.TimeFavorited)
+}
+
+func parseUnix(s string) time.Time {
+	sec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+// trackingParams are query parameters added by ad links, utm_ parameters are removed too.
+// Parameters like ref are left, some sites use them to pick the page, e.g. a branch.
+var trackingParams = map[string]bool{
+	"fbclid": true,
+	"gclid":  true,
+}
+
+// NormalizeURL returns the url in a form where http and https, the www. prefix, the trailing slash,
+// the fragment, tracking parameters and amp versions don't matter, so saved copies of one page compare equal.
+// Unparsable urls are returned as is.
+func NormalizeURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return raw
+	}
+
+	if u.Scheme == "http" {
+		u.Scheme = "https"
+	}
+	u.Host = strings.ToLower(u.Host)
+	u.Host = strings.TrimPrefix(u.Host, "www.")
+	u.Host = strings.TrimPrefix(u.Host, "amp.")
+
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	// /amp is an amp version only after the path of the page, example.com/amp is a page itself
+	switch {
+	case strings.HasSuffix(u.Path, "/amp") && strings.LastIndex(u.Path, "/") > 0:
+		u.Path = strings.TrimSuffix(u.Path, "/amp")
+	case strings.HasSuffix(u.Path, ".amp") && !strings.HasSuffix(u.Path, "/.amp"):
+		u.Path = strings.TrimSuffix(u.Path, ".amp")
+	}
+	u.RawPath = ""
+
+	if u.RawQuery != "" {
+		q := u.Query()
+		for name := range q {
+			if trackingParams[strings.ToLower(name)] || strings.HasPrefix(strings.ToLower(name), "utm_") {
+				q.Del(name)
+			}
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
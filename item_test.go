@@ -0,0 +1,79 @@
+package pocket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrieveListItem_Helpers(t *testing.T) {
+	item := RetrieveListItem{
+		GivenURL:    "http://given.com/a",
+		ResolvedURL: "https://WWW.Example.com/article?id=1",
+		Favorite:    "1",
+		TimeAdded:   "1600000000",
+		WordCount:   "1500",
+		Tags: map[string]Tag{
+			"go":  {Tag: "go"},
+			"api": {Tag: "api"},
+		},
+	}
+
+	require.Equal(t, "example.com", item.Domain())
+	require.Equal(t, []string{"api", "go"}, item.TagNames())
+	require.True(t, item.HasTag("go"))
+	require.True(t, item.IsFavorite())
+	require.Equal(t, 1500, item.Words())
+	require.Equal(t, time.Unix(1600000000, 0), item.TimeAddedAt())
+	require.True(t, item.TimeUpdatedAt().IsZero())
+	require.True(t, item.TimeReadAt().IsZero())
+	require.True(t, item.TimeFavoritedAt().IsZero())
+
+	item.ResolvedURL = ""
+	require.Equal(t, "given.com", item.Domain())
+}
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"https://example.com/a", "https://example.com/a"},
+		{"http://www.Example.com/a/", "https://example.com/a"},
+		{"https://example.com/a?b=1#top", "https://example.com/a?b=1"},
+		{"https://example.com/", "https://example.com"},
+		{"https://example.com/a?utm_source=x&utm_medium=y&b=1&fbclid=z", "https://example.com/a?b=1"},
+		{"https://example.com/a?gclid=1&ref=hn", "https://example.com/a?ref=hn"},
+		{"https://example.com/a?c=2&b=1", "https://example.com/a?b=1&c=2"},
+		{"https://example.com/a/amp/", "https://example.com/a"},
+		{"https://example.com/a/b/amp", "https://example.com/a/b"},
+		{"https://example.com/a.amp", "https://example.com/a"},
+		{"https://amp.example.com/a", "https://example.com/a"},
+		// not amp versions
+		{"https://example.com/amp", "https://example.com/amp"},
+		{"https://example.com/amp/", "https://example.com/amp"},
+		{"https://example.com/amp/a", "https://example.com/amp/a"},
+		{"https://example.com/a?amp=1", "https://example.com/a?amp=1"},
+		{"not a url", "not a url"},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.want, NormalizeURL(test.raw), test.raw)
+	}
+}
+
+func TestNormalizeURL_Different(t *testing.T) {
+	// pages which must not be taken for copies of each other
+	tests := [][2]string{
+		{"https://github.com/x/y?ref=dev", "https://github.com/x/y?ref=main"},
+		{"https://example.com/amp", "https://example.com"},
+		{"https://example.com/amp/a", "https://example.com/a"},
+		{"https://example.com/a?amp=1", "https://example.com/a?amp=2"},
+		{"https://example.com/a?b=1", "https://example.com/a?b=2"},
+	}
+
+	for _, test := range tests {
+		require.NotEqual(t, NormalizeURL(test[0]), NormalizeURL(test[1]), test[0])
+	}
+}
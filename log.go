@@ -0,0 +1,72 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const redacted = "[REDACTED]"
+
+// LogLevel has the same values as slog.Level.
+type LogLevel int
+
+const (
+	LevelDebug LogLevel = -4
+	LevelInfo  LogLevel = 0
+	LevelWarn  LogLevel = 4
+	LevelError LogLevel = 8
+)
+
+// Logger receives a message with alternating keys and values, like (*slog.Logger).Log.
+// An adapter for slog is:
+//
+//	func (l slogAdapter) Log(ctx context.Context, level pocket.LogLevel, msg string, keyvals ...interface{}) {
+//		l.Logger.Log(ctx, slog.Level(level), msg, keyvals...)
+//	}
+type Logger interface {
+	Log(ctx context.Context, level LogLevel, msg string, keyvals ...interface{})
+}
+
+var redactedKeys = map[string]bool{
+	"consumer_key": true,
+	"access_token": true,
+}
+
+// redactPayload hides credentials in a json payload before it is logged.
+func redactPayload(payload []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return redacted
+	}
+
+	data, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return redacted
+	}
+	return string(data)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		for k, val := range vv {
+			if redactedKeys[k] {
+				vv[k] = redacted
+				continue
+			}
+			vv[k] = redactValue(val)
+		}
+	case []interface{}:
+		for i, val := range vv {
+			vv[i] = redactValue(val)
+		}
+	}
+	return v
+}
+
+func (p *Pocket) log(ctx context.Context, level LogLevel, msg string, keyvals ...interface{}) {
+	if p.logger == nil {
+		return
+	}
+	p.logger.Log(ctx, level, msg, keyvals...)
+}
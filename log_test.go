@@ -0,0 +1,98 @@
+package pocket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type logEntry struct {
+	level   LogLevel
+	msg     string
+	keyvals map[string]interface{}
+}
+
+type recordLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *recordLogger) Log(ctx context.Context, level LogLevel, msg string, keyvals ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	kv := map[string]interface{}{}
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		kv[keyvals[i].(string)] = keyvals[i+1]
+	}
+	l.entries = append(l.entries, logEntry{level: level, msg: msg, keyvals: kv})
+}
+
+func TestPocket_Logger(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Add("X-Error-Code", xPocketServerIssue)
+			w.Header().Add("X-Error", msgPocketServerIssue)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Add(headerUserRemaining, "100")
+		_, err := w.Write([]byte(`{"status":1}`))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	l := &recordLogger{}
+	p := New(consumerKey).
+		WithBaseUrl(srv.URL).
+		WithLogger(l).
+		WithRetry(2, time.Millisecond)
+	p.SetAccessToken(accessToken)
+
+	_, err := p.Add(context.Background(), &AddInput{Url: redirectURL})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+
+	require.Len(t, l.entries, 3)
+
+	failed := l.entries[0]
+	require.Equal(t, LevelWarn, failed.level)
+	require.Contains(t, failed.keyvals["payload"].(string), redirectURL)
+	require.False(t, strings.Contains(failed.keyvals["payload"].(string), accessToken))
+	require.Equal(t, addPath, failed.keyvals["path"])
+	require.Equal(t, 1, failed.keyvals["attempt"])
+	require.Equal(t, http.StatusServiceUnavailable, failed.keyvals["status"])
+	require.Equal(t, xPocketServerIssue, failed.keyvals["X-Error-Code"])
+	require.Equal(t, msgPocketServerIssue, failed.keyvals["X-Error"])
+
+	retry := l.entries[1]
+	require.Equal(t, LevelInfo, retry.level)
+	require.Equal(t, 1, retry.keyvals["attempt"])
+
+	success := l.entries[2]
+	require.Equal(t, LevelDebug, success.level)
+	require.Equal(t, 2, success.keyvals["attempt"])
+	require.Equal(t, http.StatusOK, success.keyvals["status"])
+	require.Equal(t, "100", success.keyvals[headerUserRemaining])
+	require.Contains(t, success.keyvals, "duration")
+
+	payload := success.keyvals["payload"].(string)
+	require.Contains(t, payload, redirectURL)
+	require.False(t, strings.Contains(payload, consumerKey))
+	require.False(t, strings.Contains(payload, accessToken))
+}
+
+func TestRedactPayload(t *testing.T) {
+	payload := redactPayload([]byte(`{"consumer_key":"ck","access_token":"at","actions":[{"access_token":"at2","url":"u"}]}`))
+	require.JSONEq(t, `{"consumer_key":"[REDACTED]","access_token":"[REDACTED]","actions":[{"access_token":"[REDACTED]","url":"u"}]}`, payload)
+
+	require.Equal(t, redacted, redactPayload([]byte("not json")))
+}
@@ -0,0 +1,239 @@
+package pocket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultIdleTimeout = 30 * time.Minute
+	defaultConcurrency = 4
+)
+
+// TokenStore returns the access token of a user managed by Manager.
+type TokenStore interface {
+	AccessToken(ctx context.Context, userID string) (string, error)
+}
+
+type TokenStoreFunc func(ctx context.Context, userID string) (string, error)
+
+func (f TokenStoreFunc) AccessToken(ctx context.Context, userID string) (string, error) {
+	return f(ctx, userID)
+}
+
+type managedClient struct {
+	pocket   *Pocket
+	lastUsed time.Time
+}
+
+// Manager keeps one client per user. All the clients share the http client
+// and the consumer key rate limit state.
+type Manager struct {
+	consumerKey string
+	store       TokenStore
+	baseURL     string
+	httpClient  *http.Client
+	keyLimit    *RateLimitState
+	idleTimeout time.Duration
+
+	mu        sync.Mutex
+	clients   map[string]*managedClient
+	lastSweep time.Time
+}
+
+func NewManager(consumerKey string, store TokenStore) *Manager {
+	return &Manager{
+		consumerKey: consumerKey,
+		store:       store,
+		baseURL:     baseURL,
+		httpClient: &http.Client{
+			Timeout:   time.Second * 5,
+			Transport: http.DefaultTransport.(*http.Transport).Clone(),
+		},
+		keyLimit:    NewRateLimitState(),
+		idleTimeout: defaultIdleTimeout,
+		clients:     make(map[string]*managedClient),
+		lastSweep:   time.Now(),
+	}
+}
+
+func (m *Manager) WithBaseUrl(baseURL string) *Manager {
+	m.baseURL = baseURL
+	return m
+}
+
+func (m *Manager) WithHttpClient(client *http.Client) *Manager {
+	m.httpClient = client
+	return m
+}
+
+// WithIdleTimeout sets how long an unused client is kept before it is evicted.
+func (m *Manager) WithIdleTimeout(d time.Duration) *Manager {
+	m.idleTimeout = d
+	return m
+}
+
+// KeyRateLimit returns the consumer key rate limit shared by all the clients.
+func (m *Manager) KeyRateLimit() RateLimit {
+	return m.keyLimit.Get()
+}
+
+// Client returns the client of the user, creating it with the token from the store if needed.
+func (m *Manager) Client(ctx context.Context, userID string) (*Pocket, error) {
+	now := time.Now()
+
+	m.mu.Lock()
+	if now.Sub(m.lastSweep) >= m.idleTimeout {
+		m.evictIdle(now)
+	}
+	if mc, ok := m.clients[userID]; ok {
+		mc.lastUsed = now
+		m.mu.Unlock()
+		return mc.pocket, nil
+	}
+	m.mu.Unlock()
+
+	token, err := m.store.AccessToken(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting access token of user %s: %w", userID, err)
+	}
+
+	p := New(m.consumerKey).
+		WithBaseUrl(m.baseURL).
+		WithHttpClient(m.httpClient).
+		WithKeyRateLimit(m.keyLimit)
+	p.SetAccessToken(token)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if mc, ok := m.clients[userID]; ok {
+		mc.lastUsed = now
+		return mc.pocket, nil
+	}
+	m.clients[userID] = &managedClient{pocket: p, lastUsed: now}
+
+	return p, nil
+}
+
+// Evict removes the client of the user, e.g. after the token was revoked.
+func (m *Manager) Evict(userID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.clients, userID)
+}
+
+// EvictIdle removes clients unused for longer than the idle timeout and returns their number.
+func (m *Manager) EvictIdle() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.evictIdle(time.Now())
+}
+
+func (m *Manager) evictIdle(now time.Time) int {
+	evicted := 0
+	for userID, mc := range m.clients {
+		if now.Sub(mc.lastUsed) >= m.idleTimeout {
+			delete(m.clients, userID)
+			evicted++
+		}
+	}
+	m.lastSweep = now
+	return evicted
+}
+
+// Len returns the number of cached clients.
+func (m *Manager) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.clients)
+}
+
+// ForEach calls fn for every user running at most concurrency calls at the same time.
+// Errors are returned as MultiError keyed by user id.
+func (m *Manager) ForEach(
+	ctx context.Context,
+	userIDs []string,
+	concurrency int,
+	fn func(ctx context.Context, userID string, p *Pocket) error,
+) error {
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs = MultiError{}
+		sem  = make(chan struct{}, concurrency)
+	)
+
+	for _, userID := range userIDs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			errs[userID] = ctx.Err()
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(userID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := m.call(ctx, userID, fn)
+			if err != nil {
+				mu.Lock()
+				errs[userID] = err
+				mu.Unlock()
+			}
+		}(userID)
+	}
+	wg.Wait()
+
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+func (m *Manager) call(
+	ctx context.Context,
+	userID string,
+	fn func(ctx context.Context, userID string, p *Pocket) error,
+) error {
+	p, err := m.Client(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return fn(ctx, userID, p)
+}
+
+// RetrieveAll runs Retrieve for every user. Responses of successful calls are
+// returned even if some of the calls failed.
+func (m *Manager) RetrieveAll(
+	ctx context.Context,
+	userIDs []string,
+	concurrency int,
+	rd *RetrieveInput,
+) (map[string]*RetrieveResponse, error) {
+	var mu sync.Mutex
+	res := make(map[string]*RetrieveResponse, len(userIDs))
+
+	err := m.ForEach(ctx, userIDs, concurrency, func(ctx context.Context, userID string, p *Pocket) error {
+		resp, err := p.Retrieve(ctx, rd)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		res[userID] = resp
+		mu.Unlock()
+		return nil
+	})
+
+	return res, err
+}
@@ -0,0 +1,116 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errUnknownUser = errors.New("unknown user")
+
+func mapTokenStore(tokens map[string]string) TokenStore {
+	return TokenStoreFunc(func(ctx context.Context, userID string) (string, error) {
+		token, ok := tokens[userID]
+		if !ok {
+			return "", errUnknownUser
+		}
+		return token, nil
+	})
+}
+
+func TestManager_Client(t *testing.T) {
+	var calls int32
+	store := TokenStoreFunc(func(ctx context.Context, userID string) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return accessToken + "-" + userID, nil
+	})
+
+	m := NewManager(consumerKey, store)
+
+	p1, err := m.Client(context.Background(), "user1")
+	require.NoError(t, err)
+	require.Equal(t, accessToken+"-user1", p1.GetAccessToken())
+
+	p2, err := m.Client(context.Background(), "user1")
+	require.NoError(t, err)
+	require.Same(t, p1, p2)
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	_, err = m.Client(context.Background(), "user2")
+	require.NoError(t, err)
+	require.Equal(t, 2, m.Len())
+
+	m.Evict("user1")
+	require.Equal(t, 1, m.Len())
+}
+
+func TestManager_EvictIdle(t *testing.T) {
+	m := NewManager(consumerKey, mapTokenStore(map[string]string{"user1": accessToken})).
+		WithIdleTimeout(time.Millisecond)
+
+	_, err := m.Client(context.Background(), "user1")
+	require.NoError(t, err)
+
+	time.Sleep(5 * time.Millisecond)
+	require.Equal(t, 1, m.EvictIdle())
+	require.Equal(t, 0, m.Len())
+}
+
+func TestManager_RetrieveAll(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		req := retrieveRequest{}
+		require.NoError(t, json.Unmarshal(data, &req))
+		require.Equal(t, consumerKey, req.ConsumerKey)
+
+		w.Header().Add(headerKeyLimit, "10000")
+		w.Header().Add(headerKeyRemaining, "9999")
+		w.Header().Add(headerKeyReset, "3600")
+
+		if req.AccessToken == "bad-token" {
+			w.Header().Add("X-Error-Code", xUnauthorized)
+			w.Header().Add("X-Error", msgUnauthorized)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		data, err = json.Marshal(&RetrieveResponse{Status: successStatus})
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	m := NewManager(consumerKey, mapTokenStore(map[string]string{
+		"user1": accessToken,
+		"user2": accessToken,
+		"user3": "bad-token",
+	})).WithBaseUrl(srv.URL)
+
+	res, err := m.RetrieveAll(context.Background(), []string{"user1", "user2", "user3", "user4"}, 2, &RetrieveInput{})
+
+	require.Len(t, res, 2)
+	require.Equal(t, successStatus, res["user1"].Status)
+	require.Equal(t, successStatus, res["user2"].Status)
+
+	var merr MultiError
+	require.True(t, errors.As(err, &merr))
+	require.Len(t, merr, 2)
+
+	var perr *ErrorPocket
+	require.True(t, errors.As(merr["user3"], &perr))
+	require.Equal(t, xUnauthorized, perr.Xcode)
+	require.ErrorIs(t, merr["user4"], errUnknownUser)
+
+	require.Equal(t, 9999, m.KeyRateLimit().Remaining)
+}
@@ -0,0 +1,132 @@
+package pocket
+
+import (
+	"errors"
+	"expvar"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Endpoint string
+
+const (
+	EndpointAdd   Endpoint = "add"
+	EndpointGet   Endpoint = "get"
+	EndpointSend  Endpoint = "send"
+	EndpointOAuth Endpoint = "oauth"
+)
+
+func endpointOf(pocketPath string) Endpoint {
+	switch pocketPath {
+	case addPath:
+		return EndpointAdd
+	case retrievePath:
+		return EndpointGet
+	case modifyPath:
+		return EndpointSend
+	case requestTokenPath, accessTokenPath:
+		return EndpointOAuth
+	}
+	return Endpoint(strings.Trim(pocketPath, "/"))
+}
+
+// Metrics is called by the client for every request.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	IncRequests(endpoint Endpoint)
+	IncErrors(endpoint Endpoint, xcode string) // xcode is empty for errors not returned by Pocket
+	IncRetries(endpoint Endpoint)
+	ObserveLatency(endpoint Endpoint, d time.Duration)
+}
+
+func (p *Pocket) observeRequest(endpoint Endpoint, start time.Time, err error) {
+	if p.metrics == nil {
+		return
+	}
+
+	p.metrics.IncRequests(endpoint)
+	p.metrics.ObserveLatency(endpoint, time.Since(start))
+	if err != nil {
+		xcode := ""
+		var perr *ErrorPocket
+		if errors.As(err, &perr) {
+			xcode = perr.Xcode
+		}
+		p.metrics.IncErrors(endpoint, xcode)
+	}
+}
+
+func (p *Pocket) observeRetry(endpoint Endpoint) {
+	if p.metrics == nil {
+		return
+	}
+	p.metrics.IncRetries(endpoint)
+}
+
+// ExpvarMetrics publishes metrics with expvar:
+//
+//	requests         - endpoint -> number of requests
+//	errors           - endpoint.xcode -> number of failed requests
+//	retries          - endpoint -> number of retried attempts
+//	latency_count    - endpoint -> number of latency observations
+//	latency_sum_ms   - endpoint -> total latency in milliseconds
+type ExpvarMetrics struct {
+	requests     *expvar.Map
+	errors       *expvar.Map
+	retries      *expvar.Map
+	latencyCount *expvar.Map
+	latencySum   *expvar.Map
+}
+
+// publishMu makes finding and publishing a map under a name one step
+var publishMu sync.Mutex
+
+// NewExpvarMetrics publishes the metrics under the name. Metrics created with the same name share the
+// published counters, so clients of several accounts can report together.
+// Like expvar.Publish it panics if the name is used by a variable other than an *expvar.Map.
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	publishMu.Lock()
+	defer publishMu.Unlock()
+
+	root, ok := expvar.Get(name).(*expvar.Map)
+	if !ok {
+		root = expvar.NewMap(name)
+	}
+	return &ExpvarMetrics{
+		requests:     subMap(root, "requests"),
+		errors:       subMap(root, "errors"),
+		retries:      subMap(root, "retries"),
+		latencyCount: subMap(root, "latency_count"),
+		latencySum:   subMap(root, "latency_sum_ms"),
+	}
+}
+
+func subMap(root *expvar.Map, key string) *expvar.Map {
+	if m, ok := root.Get(key).(*expvar.Map); ok {
+		return m
+	}
+	m := new(expvar.Map).Init()
+	root.Set(key, m)
+	return m
+}
+
+func (m *ExpvarMetrics) IncRequests(endpoint Endpoint) {
+	m.requests.Add(string(endpoint), 1)
+}
+
+func (m *ExpvarMetrics) IncErrors(endpoint Endpoint, xcode string) {
+	if xcode == "" {
+		xcode = "unknown"
+	}
+	m.errors.Add(string(endpoint)+"."+xcode, 1)
+}
+
+func (m *ExpvarMetrics) IncRetries(endpoint Endpoint) {
+	m.retries.Add(string(endpoint), 1)
+}
+
+func (m *ExpvarMetrics) ObserveLatency(endpoint Endpoint, d time.Duration) {
+	m.latencyCount.Add(string(endpoint), 1)
+	m.latencySum.AddFloat(string(endpoint), float64(d)/float64(time.Millisecond))
+}
@@ -0,0 +1,98 @@
+package pocket
+
+import (
+	"context"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPocket_Metrics(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch {
+		case r.URL.Path == modifyPath:
+			w.Header().Add("X-Error-Code", xUnauthorized)
+			w.Header().Add("X-Error", msgUnauthorized)
+			w.WriteHeader(http.StatusUnauthorized)
+		case calls == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, err := w.Write([]byte(`{"status":1}`))
+			require.NoError(t, err)
+		}
+	}))
+	defer srv.Close()
+
+	// counters are shared with earlier runs of the test, only their changes are checked
+	NewExpvarMetrics("pocket_test_metrics")
+	root := expvar.Get("pocket_test_metrics").(*expvar.Map)
+	get := func(name, key string) float64 {
+		v := root.Get(name).(*expvar.Map).Get(key)
+		if v == nil {
+			return 0
+		}
+		f, err := strconv.ParseFloat(v.String(), 64)
+		require.NoError(t, err)
+		return f
+	}
+	keys := [][2]string{
+		{"requests", string(EndpointGet)},
+		{"requests", string(EndpointSend)},
+		{"retries", string(EndpointGet)},
+		{"retries", string(EndpointSend)},
+		{"errors", string(EndpointGet) + ".unknown"},
+		{"errors", string(EndpointSend) + "." + xUnauthorized},
+		{"latency_count", string(EndpointGet)},
+		{"latency_sum_ms", string(EndpointGet)},
+	}
+	before := map[[2]string]float64{}
+	for _, k := range keys {
+		before[k] = get(k[0], k[1])
+	}
+	delta := func(name, key string) float64 {
+		return get(name, key) - before[[2]string{name, key}]
+	}
+
+	// a second client with the same name doesn't panic and reports into the same counters
+	m := NewExpvarMetrics("pocket_test_metrics")
+	p := New(consumerKey).WithBaseUrl(srv.URL).WithMetrics(m).WithRetry(2, time.Millisecond)
+
+	_, err := p.Retrieve(context.Background(), &RetrieveInput{})
+	require.NoError(t, err)
+	_, err = p.Modify(context.Background(), Actions{})
+	require.Error(t, err)
+
+	require.Equal(t, 1.0, delta("requests", string(EndpointGet)))
+	require.Equal(t, 1.0, delta("requests", string(EndpointSend)))
+	require.Equal(t, 1.0, delta("retries", string(EndpointGet)))
+	require.Equal(t, 0.0, delta("retries", string(EndpointSend)))
+	require.Equal(t, 0.0, delta("errors", string(EndpointGet)+".unknown"))
+	require.Equal(t, 1.0, delta("errors", string(EndpointSend)+"."+xUnauthorized))
+	require.Equal(t, 1.0, delta("latency_count", string(EndpointGet)))
+	require.Greater(t, delta("latency_sum_ms", string(EndpointGet)), 0.0)
+
+}
+
+func TestNewExpvarMetrics_NameTaken(t *testing.T) {
+	if expvar.Get("pocket_test_metrics_int") == nil {
+		expvar.NewInt("pocket_test_metrics_int")
+	}
+	require.Panics(t, func() {
+		NewExpvarMetrics("pocket_test_metrics_int")
+	})
+}
+
+func TestEndpointOf(t *testing.T) {
+	require.Equal(t, EndpointAdd, endpointOf(addPath))
+	require.Equal(t, EndpointGet, endpointOf(retrievePath))
+	require.Equal(t, EndpointSend, endpointOf(modifyPath))
+	require.Equal(t, EndpointOAuth, endpointOf(requestTokenPath))
+	require.Equal(t, EndpointOAuth, endpointOf(accessTokenPath))
+}
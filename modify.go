@@ -2,6 +2,8 @@ package pocket
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 )
 
 type ActionType string
@@ -78,11 +80,15 @@ type (
 
 	ModifyResponse struct {
 		ActionResult []interface{} `json:"action_results"`
+		ActionErrors []interface{} `json:"action_errors"`
 		Status       int           `json:"status"`
 	}
 )
 
-func (p *Pocket) Modify(ctx context.Context, actions Actions) (*ModifyResponse, error) {
+func (p *Pocket) Modify(ctx context.Context, actions Actions) (_ *ModifyResponse, err error) {
+	ctx, end := p.startSpan(ctx, SpanModify, SpanAttrs{AttrActionCount: len(actions)})
+	defer func() { end(err) }()
+
 	req := modifyRequest{
 		Actions:     actions,
 		ConsumerKey: p.consumerKey,
@@ -90,10 +96,77 @@ func (p *Pocket) Modify(ctx context.Context, actions Actions) (*ModifyResponse,
 	}
 
 	res := ModifyResponse{}
-	err := p.doRequest(ctx, modifyPath, req, &res)
+	err = p.doRequest(ctx, modifyPath, req, &res)
 	if err != nil {
 		return nil, err
 	}
 
 	return &res, nil
 }
+
+// ActionFields holds the fields of any action, so actions can be inspected without a type switch.
+type ActionFields struct {
+	Action ActionType `json:"action"`
+	ItemID int64      `json:"item_id,omitempty"`
+	RefID  int64      `json:"ref_id,omitempty"`
+	Tags   string     `json:"tags,omitempty"`
+	Tag    string     `json:"tag,omitempty"`
+	OldTag string     `json:"old_tag,omitempty"`
+	NewTag string     `json:"new_tag,omitempty"`
+	Time   int64      `json:"time,omitempty"`
+	Title  string     `json:"title,omitempty"`
+	Url    string     `json:"url,omitempty"`
+}
+
+// ParseAction returns the fields of an action of Actions.
+func ParseAction(a interface{}) (ActionFields, error) {
+	f := ActionFields{}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		return f, fmt.Errorf("error while marshalling action: %w", err)
+	}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return f, fmt.Errorf("error while unmarshalling action: %w", err)
+	}
+	if f.Action == "" {
+		return f, fmt.Errorf("action type is empty")
+	}
+
+	return f, nil
+}
+
+// Typed returns the action as the action type of its Action field.
+func (f ActionFields) Typed() (interface{}, error) {
+	a := action{Action: f.Action, ItemID: f.ItemID, Time: f.Time}
+	ta := tagsAction{Action: f.Action, ItemID: f.ItemID, Tags: f.Tags, Time: f.Time}
+
+	switch f.Action {
+	case ActionAddType:
+		return &ActionAdd{Action: f.Action, RefID: f.RefID, Tags: f.Tags, Time: f.Time, Title: f.Title, Url: f.Url}, nil
+	case ActionArchiveType:
+		return (*ActionArchive)(&a), nil
+	case ActionReaddType:
+		return (*ActionReadd)(&a), nil
+	case ActionFavoriteType:
+		return (*ActionFavorite)(&a), nil
+	case ActionUnfavoriteType:
+		return (*ActionUnfavorite)(&a), nil
+	case ActionDeleteType:
+		return (*ActionDelete)(&a), nil
+	case ActionTagsAddType:
+		return (*ActionTagsAdd)(&ta), nil
+	case ActionTagsRemoveType:
+		return (*ActionTagsRemove)(&ta), nil
+	case ActionTagsReplaceType:
+		return (*ActionTagsReplace)(&ta), nil
+	case ActionTagsClearType:
+		return (*ActionTagsClear)(&a), nil
+	case ActionTagRenameType:
+		return &ActionTagRename{Action: f.Action, OldTag: f.OldTag, NewTag: f.NewTag, Time: f.Time}, nil
+	case ActionTagDeleteType:
+		return &ActionTagDelete{Action: f.Action, Tag: f.Tag, Time: f.Time}, nil
+	}
+
+	return nil, fmt.Errorf("unknown action type %q", f.Action)
+}
@@ -240,3 +240,35 @@ func TestPocket_Modify(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAction(t *testing.T) {
+	actions := Actions{
+		&ActionAdd{Action: ActionAddType, Url: redirectURL, Tags: "a,b", Time: 10},
+		&ActionArchive{Action: ActionArchiveType, ItemID: 1},
+		ActionFavorite{Action: ActionFavoriteType, ItemID: 2},
+		&ActionTagsReplace{Action: ActionTagsReplaceType, ItemID: 3, Tags: "c"},
+		&ActionTagRename{Action: ActionTagRenameType, OldTag: "a", NewTag: "b"},
+		&ActionTagDelete{Action: ActionTagDeleteType, Tag: "d"},
+	}
+
+	for _, a := range actions {
+		f, err := ParseAction(a)
+		require.NoError(t, err)
+
+		typed, err := f.Typed()
+		require.NoError(t, err)
+
+		exp, err := json.Marshal(a)
+		require.NoError(t, err)
+		act, err := json.Marshal(typed)
+		require.NoError(t, err)
+		require.JSONEq(t, string(exp), string(act))
+	}
+
+	f, err := ParseAction(ActionFavorite{Action: ActionFavoriteType, ItemID: 2})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), f.ItemID)
+
+	_, err = ParseAction(struct{}{})
+	require.Error(t, err)
+}
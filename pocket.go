@@ -14,6 +14,7 @@ import (
 
 const (
 	baseURL          = "https://getpocket.com/"
+	authBaseURL      = "https://getpocket.com/"
 	requestTokenPath = "/v3/oauth/request"
 	accessTokenPath  = "/v3/oauth/authorize"
 	authPath         = "/auth/authorize"
@@ -23,11 +24,19 @@ const (
 )
 
 type Pocket struct {
-	consumerKey  string
-	requestToken string
-	accessToken  string
-	baseURL      string
-	httpClient   *http.Client
+	consumerKey   string
+	requestToken  string
+	accessToken   string
+	baseURL       string
+	authBaseURL   string
+	httpClient    *http.Client
+	keyLimit      *RateLimitState
+	userLimit     *RateLimitState
+	logger        Logger
+	metrics       Metrics
+	tracer        Tracer
+	retryAttempts int
+	retryBackoff  time.Duration
 }
 
 func New(consumerKey string) *Pocket {
@@ -36,7 +45,10 @@ func New(consumerKey string) *Pocket {
 		httpClient: &http.Client{
 			Timeout: time.Second * 5,
 		},
-		baseURL: baseURL,
+		baseURL:     baseURL,
+		authBaseURL: authBaseURL,
+		keyLimit:    NewRateLimitState(),
+		userLimit:   NewRateLimitState(),
 	}
 }
 
@@ -45,12 +57,68 @@ func (p *Pocket) WithBaseUrl(baseURL string) *Pocket {
 	return p
 }
 
+// WithAuthBaseUrl sets the base url of the page the user is redirected to for
+// authorization. It is independent of the API base url, so the API can be
+// reached through a proxy while users still authorize on Pocket itself.
+func (p *Pocket) WithAuthBaseUrl(authBaseURL string) *Pocket {
+	p.authBaseURL = authBaseURL
+	return p
+}
+
 func (p *Pocket) WithHttpClient(client *http.Client) *Pocket {
 	p.httpClient = client
 	return p
 }
 
-func (p *Pocket) doRequestRaw(ctx context.Context, pocketPath string, reqData interface{}) ([]byte, error) {
+// WithLogger sets the logger of requests. Credentials are redacted from logged payloads.
+func (p *Pocket) WithLogger(l Logger) *Pocket {
+	p.logger = l
+	return p
+}
+
+// WithMetrics sets the metrics the client reports requests, errors, retries and latency to.
+func (p *Pocket) WithMetrics(m Metrics) *Pocket {
+	p.metrics = m
+	return p
+}
+
+// WithTracer sets the tracer. Spans are started for every request and every attempt of it.
+func (p *Pocket) WithTracer(t Tracer) *Pocket {
+	p.tracer = t
+	return p
+}
+
+// WithRetry makes the client send a request up to attempts times if it failed with
+// a 429 or 503 status, Retrieve is retried after network errors and any 5xx status too.
+// The backoff doubles after every attempt.
+func (p *Pocket) WithRetry(attempts int, backoff time.Duration) *Pocket {
+	p.retryAttempts = attempts
+	p.retryBackoff = backoff
+	return p
+}
+
+// WithKeyRateLimit sets the state where the consumer key rate limit is kept.
+// Clients sharing a consumer key should share the state too.
+func (p *Pocket) WithKeyRateLimit(state *RateLimitState) *Pocket {
+	p.keyLimit = state
+	return p
+}
+
+// KeyRateLimit returns the last rate limit of the consumer key reported by Pocket.
+func (p *Pocket) KeyRateLimit() RateLimit {
+	return p.keyLimit.Get()
+}
+
+// UserRateLimit returns the last rate limit of the user reported by Pocket.
+func (p *Pocket) UserRateLimit() RateLimit {
+	return p.userLimit.Get()
+}
+
+func (p *Pocket) doRequestRaw(ctx context.Context, pocketPath string, reqData interface{}) (data []byte, err error) {
+	endpoint := endpointOf(pocketPath)
+	ctx, end := p.startSpan(ctx, SpanRequest, SpanAttrs{AttrEndpoint: string(endpoint)})
+	defer func() { end(err) }()
+
 	u, err := url.Parse(p.baseURL)
 	if err != nil {
 		return nil, fmt.Errorf("error while parsing base url: %w", err)
@@ -62,33 +130,101 @@ func (p *Pocket) doRequestRaw(ctx context.Context, pocketPath string, reqData in
 		return nil, fmt.Errorf("error while marshalling request body: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewBuffer(body))
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		var retry bool
+		data, retry, err = p.doAttempt(ctx, u.String(), pocketPath, body, attempt)
+		if err == nil || !retry || attempt >= p.retryAttempts {
+			p.observeRequest(endpoint, start, err)
+			return data, err
+		}
+		p.observeRetry(endpoint)
+
+		backoff := p.retryBackoff << (attempt - 1)
+		p.log(ctx, LevelInfo, "retrying pocket request",
+			"path", pocketPath,
+			"attempt", attempt,
+			"backoff", backoff,
+			"error", err,
+		)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			err = fmt.Errorf("error while waiting for retry: %w", ctx.Err())
+			p.observeRequest(endpoint, start, err)
+			return nil, err
+		}
+	}
+}
+
+// doAttempt sends the request once. retry reports whether the failed request may succeed if sent again.
+func (p *Pocket) doAttempt(
+	ctx context.Context,
+	reqURL string,
+	pocketPath string,
+	body []byte,
+	attempt int,
+) (data []byte, retry bool, err error) {
+	ctx, end := p.startSpan(ctx, SpanAttempt, SpanAttrs{
+		AttrEndpoint: string(endpointOf(pocketPath)),
+		AttrAttempt:  attempt,
+	})
+	start := time.Now()
+	keyvals := []interface{}{"path", pocketPath, "attempt", attempt}
+	defer func() {
+		end(err)
+		keyvals = append(keyvals, "duration", time.Since(start))
+		keyvals = append(keyvals, "payload", redactPayload(body))
+		if err != nil {
+			p.log(ctx, LevelWarn, "pocket request failed", append(keyvals, "error", err)...)
+		} else {
+			p.log(ctx, LevelDebug, "pocket request", keyvals...)
+		}
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, bytes.NewBuffer(body))
 	if err != nil {
-		return nil, fmt.Errorf("error while building request: %w", err)
+		return nil, false, fmt.Errorf("error while building request: %w", err)
 	}
 	req.Header.Set("X-Accept", "application/json")
 	req.Header.Set("Content-type", "application/json; charset=UTF8")
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error while sending request: %w", err)
+		return nil, ctx.Err() == nil && canRetry(pocketPath, 0), fmt.Errorf("error while sending request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	p.keyLimit.update(resp.Header, headerKeyLimit, headerKeyRemaining, headerKeyReset)
+	p.userLimit.update(resp.Header, headerUserLimit, headerUserRemaining, headerUserReset)
+
+	keyvals = append(keyvals, "status", resp.StatusCode)
+	for _, h := range []string{
+		"X-Error", "X-Error-Code",
+		headerUserLimit, headerUserRemaining, headerUserReset,
+		headerKeyLimit, headerKeyRemaining, headerKeyReset,
+	} {
+		if v := resp.Header.Get(h); v != "" {
+			keyvals = append(keyvals, h, v)
+		}
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return nil, NewErrorPocket(
+		return nil, canRetry(pocketPath, resp.StatusCode), NewErrorPocket(
 			resp.Header.Get("X-Error"),
 			resp.Header.Get("X-Error-Code"),
 			resp.StatusCode,
 		)
 	}
 
-	data, err := io.ReadAll(resp.Body)
+	data, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("error while reading data from response: %w", err)
+		return nil, canRetry(pocketPath, 0), fmt.Errorf("error while reading data from response: %w", err)
 	}
 
-	return data, nil
+	return data, false, nil
 }
 
 func (p *Pocket) doRequest(ctx context.Context, path string, reqData interface{}, res interface{}) error {
@@ -0,0 +1,298 @@
+// Package pocketmock provides a mock of pocket.Client.
+package pocketmock
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+// ErrNotProgrammed is returned by mocked methods without a programmed func.
+var ErrNotProgrammed = errors.New("pocketmock: method is not programmed")
+
+var _ pocket.Client = (*MockClient)(nil)
+
+type (
+	AddCall struct {
+		Ctx context.Context
+		Ad  *pocket.AddInput
+	}
+
+	RetrieveCall struct {
+		Ctx context.Context
+		Rd  *pocket.RetrieveInput
+	}
+
+	ModifyCall struct {
+		Ctx     context.Context
+		Actions pocket.Actions
+	}
+
+	WatchCall struct {
+		Ctx      context.Context
+		Interval time.Duration
+		Filter   *pocket.RetrieveInput
+	}
+
+	GenerateRequestTokenCall struct {
+		Ctx         context.Context
+		RedirectURI string
+	}
+
+	GenerateAccessTokenCall struct {
+		Ctx context.Context
+	}
+
+	AuthAppCall struct {
+		Ctx         context.Context
+		RedirectURI string
+	}
+
+	AuthUserCall struct {
+		Ctx context.Context
+	}
+
+	MakeAuthUrlCall struct {
+		RedirectUri string
+		Opts        []pocket.AuthUrlOption
+	}
+)
+
+// MockClient implements pocket.Client. Program it by setting the *Func fields,
+// calls of every method are recorded and returned by the *Calls methods.
+//
+//	m := &pocketmock.MockClient{
+//		AddFunc: func(ctx context.Context, ad *pocket.AddInput) (*pocket.AddResponse, error) {
+//			return &pocket.AddResponse{Status: 1}, nil
+//		},
+//	}
+//
+// Methods without a func return zero values and ErrNotProgrammed, Watch returns
+// a closed channel. Tokens are kept by the mock itself unless the getter and setter
+// funcs are set.
+type MockClient struct {
+	AddFunc                  func(ctx context.Context, ad *pocket.AddInput) (*pocket.AddResponse, error)
+	RetrieveFunc             func(ctx context.Context, rd *pocket.RetrieveInput) (*pocket.RetrieveResponse, error)
+	ModifyFunc               func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error)
+	WatchFunc                func(ctx context.Context, interval time.Duration, filter *pocket.RetrieveInput) <-chan pocket.Event
+	GenerateRequestTokenFunc func(ctx context.Context, redirectURI string) (*pocket.AuthAppResponse, error)
+	GenerateAccessTokenFunc  func(ctx context.Context) (*pocket.AuthUserResponse, error)
+	AuthAppFunc              func(ctx context.Context, redirectURI string) error
+	AuthUserFunc             func(ctx context.Context) error
+	MakeAuthUrlFunc          func(redirectUri string, opts ...pocket.AuthUrlOption) (string, error)
+	GetRequestTokenFunc      func() string
+	SetRequestTokenFunc      func(requestToken string)
+	GetAccessTokenFunc       func() string
+	SetAccessTokenFunc       func(at string)
+
+	mu           sync.RWMutex
+	requestToken string
+	accessToken  string
+	calls        struct {
+		add                  []AddCall
+		retrieve             []RetrieveCall
+		modify               []ModifyCall
+		watch                []WatchCall
+		generateRequestToken []GenerateRequestTokenCall
+		generateAccessToken  []GenerateAccessTokenCall
+		authApp              []AuthAppCall
+		authUser             []AuthUserCall
+		makeAuthUrl          []MakeAuthUrlCall
+	}
+}
+
+func (m *MockClient) Add(ctx context.Context, ad *pocket.AddInput) (*pocket.AddResponse, error) {
+	m.mu.Lock()
+	m.calls.add = append(m.calls.add, AddCall{Ctx: ctx, Ad: ad})
+	m.mu.Unlock()
+
+	if m.AddFunc == nil {
+		return nil, ErrNotProgrammed
+	}
+	return m.AddFunc(ctx, ad)
+}
+
+func (m *MockClient) AddCalls() []AddCall {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]AddCall(nil), m.calls.add...)
+}
+
+func (m *MockClient) Retrieve(ctx context.Context, rd *pocket.RetrieveInput) (*pocket.RetrieveResponse, error) {
+	m.mu.Lock()
+	m.calls.retrieve = append(m.calls.retrieve, RetrieveCall{Ctx: ctx, Rd: rd})
+	m.mu.Unlock()
+
+	if m.RetrieveFunc == nil {
+		return nil, ErrNotProgrammed
+	}
+	return m.RetrieveFunc(ctx, rd)
+}
+
+func (m *MockClient) RetrieveCalls() []RetrieveCall {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]RetrieveCall(nil), m.calls.retrieve...)
+}
+
+func (m *MockClient) Modify(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+	m.mu.Lock()
+	m.calls.modify = append(m.calls.modify, ModifyCall{Ctx: ctx, Actions: actions})
+	m.mu.Unlock()
+
+	if m.ModifyFunc == nil {
+		return nil, ErrNotProgrammed
+	}
+	return m.ModifyFunc(ctx, actions)
+}
+
+func (m *MockClient) ModifyCalls() []ModifyCall {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]ModifyCall(nil), m.calls.modify...)
+}
+
+func (m *MockClient) Watch(ctx context.Context, interval time.Duration, filter *pocket.RetrieveInput) <-chan pocket.Event {
+	m.mu.Lock()
+	m.calls.watch = append(m.calls.watch, WatchCall{Ctx: ctx, Interval: interval, Filter: filter})
+	m.mu.Unlock()
+
+	if m.WatchFunc == nil {
+		events := make(chan pocket.Event)
+		close(events)
+		return events
+	}
+	return m.WatchFunc(ctx, interval, filter)
+}
+
+func (m *MockClient) WatchCalls() []WatchCall {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]WatchCall(nil), m.calls.watch...)
+}
+
+func (m *MockClient) GenerateRequestToken(ctx context.Context, redirectURI string) (*pocket.AuthAppResponse, error) {
+	m.mu.Lock()
+	m.calls.generateRequestToken = append(m.calls.generateRequestToken, GenerateRequestTokenCall{Ctx: ctx, RedirectURI: redirectURI})
+	m.mu.Unlock()
+
+	if m.GenerateRequestTokenFunc == nil {
+		return nil, ErrNotProgrammed
+	}
+	return m.GenerateRequestTokenFunc(ctx, redirectURI)
+}
+
+func (m *MockClient) GenerateRequestTokenCalls() []GenerateRequestTokenCall {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]GenerateRequestTokenCall(nil), m.calls.generateRequestToken...)
+}
+
+func (m *MockClient) GenerateAccessToken(ctx context.Context) (*pocket.AuthUserResponse, error) {
+	m.mu.Lock()
+	m.calls.generateAccessToken = append(m.calls.generateAccessToken, GenerateAccessTokenCall{Ctx: ctx})
+	m.mu.Unlock()
+
+	if m.GenerateAccessTokenFunc == nil {
+		return nil, ErrNotProgrammed
+	}
+	return m.GenerateAccessTokenFunc(ctx)
+}
+
+func (m *MockClient) GenerateAccessTokenCalls() []GenerateAccessTokenCall {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]GenerateAccessTokenCall(nil), m.calls.generateAccessToken...)
+}
+
+func (m *MockClient) AuthApp(ctx context.Context, redirectURI string) error {
+	m.mu.Lock()
+	m.calls.authApp = append(m.calls.authApp, AuthAppCall{Ctx: ctx, RedirectURI: redirectURI})
+	m.mu.Unlock()
+
+	if m.AuthAppFunc == nil {
+		return ErrNotProgrammed
+	}
+	return m.AuthAppFunc(ctx, redirectURI)
+}
+
+func (m *MockClient) AuthAppCalls() []AuthAppCall {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]AuthAppCall(nil), m.calls.authApp...)
+}
+
+func (m *MockClient) AuthUser(ctx context.Context) error {
+	m.mu.Lock()
+	m.calls.authUser = append(m.calls.authUser, AuthUserCall{Ctx: ctx})
+	m.mu.Unlock()
+
+	if m.AuthUserFunc == nil {
+		return ErrNotProgrammed
+	}
+	return m.AuthUserFunc(ctx)
+}
+
+func (m *MockClient) AuthUserCalls() []AuthUserCall {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]AuthUserCall(nil), m.calls.authUser...)
+}
+
+func (m *MockClient) MakeAuthUrl(redirectUri string, opts ...pocket.AuthUrlOption) (string, error) {
+	m.mu.Lock()
+	m.calls.makeAuthUrl = append(m.calls.makeAuthUrl, MakeAuthUrlCall{RedirectUri: redirectUri, Opts: opts})
+	m.mu.Unlock()
+
+	if m.MakeAuthUrlFunc == nil {
+		return "", ErrNotProgrammed
+	}
+	return m.MakeAuthUrlFunc(redirectUri, opts...)
+}
+
+func (m *MockClient) MakeAuthUrlCalls() []MakeAuthUrlCall {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]MakeAuthUrlCall(nil), m.calls.makeAuthUrl...)
+}
+
+func (m *MockClient) GetRequestToken() string {
+	if m.GetRequestTokenFunc != nil {
+		return m.GetRequestTokenFunc()
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.requestToken
+}
+
+func (m *MockClient) SetRequestToken(requestToken string) {
+	if m.SetRequestTokenFunc != nil {
+		m.SetRequestTokenFunc(requestToken)
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requestToken = requestToken
+}
+
+func (m *MockClient) GetAccessToken() string {
+	if m.GetAccessTokenFunc != nil {
+		return m.GetAccessTokenFunc()
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.accessToken
+}
+
+func (m *MockClient) SetAccessToken(at string) {
+	if m.SetAccessTokenFunc != nil {
+		m.SetAccessTokenFunc(at)
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.accessToken = at
+}
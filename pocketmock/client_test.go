@@ -0,0 +1,55 @@
+package pocketmock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+// archiveAll is an example of code depending on pocket.Client.
+func archiveAll(ctx context.Context, c pocket.Client) error {
+	res, err := c.Retrieve(ctx, &pocket.RetrieveInput{State: pocket.Unread})
+	if err != nil {
+		return err
+	}
+
+	actions := pocket.Actions{}
+	for range res.List {
+		actions = append(actions, &pocket.ActionArchive{Action: pocket.ActionArchiveType})
+	}
+	_, err = c.Modify(ctx, actions)
+	return err
+}
+
+func TestMockClient(t *testing.T) {
+	errModify := errors.New("modify error")
+
+	m := &MockClient{
+		RetrieveFunc: func(ctx context.Context, rd *pocket.RetrieveInput) (*pocket.RetrieveResponse, error) {
+			return &pocket.RetrieveResponse{
+				List: map[string]pocket.RetrieveListItem{"1": {}, "2": {}},
+			}, nil
+		},
+		ModifyFunc: func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+			return nil, errModify
+		},
+	}
+
+	err := archiveAll(context.Background(), m)
+	require.ErrorIs(t, err, errModify)
+
+	require.Len(t, m.RetrieveCalls(), 1)
+	require.Equal(t, pocket.Unread, m.RetrieveCalls()[0].Rd.State)
+	require.Len(t, m.ModifyCalls(), 1)
+	require.Len(t, m.ModifyCalls()[0].Actions, 2)
+
+	_, err = m.Add(context.Background(), &pocket.AddInput{})
+	require.ErrorIs(t, err, ErrNotProgrammed)
+	require.Len(t, m.AddCalls(), 1)
+
+	m.SetAccessToken("access-token")
+	require.Equal(t, "access-token", m.GetAccessToken())
+}
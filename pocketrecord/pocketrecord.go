@@ -0,0 +1,241 @@
+// Package pocketrecord records Pocket API calls to a cassette file and replays them
+// in tests without network access.
+package pocketrecord
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+const scrubbed = "SCRUBBED"
+
+var ErrNoInteraction = errors.New("pocketrecord: no recorded interaction")
+
+var scrubbedKeys = map[string]bool{
+	"consumer_key": true,
+	"access_token": true,
+}
+
+type Mode int
+
+const (
+	ModeReplay Mode = iota
+	ModeRecord
+)
+
+type (
+	Request struct {
+		Method string `json:"method"`
+		Path   string `json:"path"`
+		Body   string `json:"body"`
+	}
+
+	Response struct {
+		Status int         `json:"status"`
+		Header http.Header `json:"header"`
+		Body   string      `json:"body"`
+	}
+
+	Interaction struct {
+		Request  Request  `json:"request"`
+		Response Response `json:"response"`
+	}
+
+	Cassette struct {
+		Interactions []Interaction `json:"interactions"`
+	}
+)
+
+// Recorder is an http.RoundTripper. In the record mode it sends requests with the
+// underlying transport and keeps them, in the replay mode it answers with recorded responses.
+type Recorder struct {
+	mode      Mode
+	path      string
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New creates a recorder for the cassette file. In the replay mode the file must exist.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		mode:      mode,
+		path:      path,
+		transport: http.DefaultTransport,
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error while reading cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("error while unmarshalling cassette: %w", err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// WithTransport sets the transport used to send requests in the record mode.
+func (r *Recorder) WithTransport(rt http.RoundTripper) *Recorder {
+	r.transport = rt
+	return r
+}
+
+// Client returns an http client using the recorder, pass it to pocket.WithHttpClient.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("error while reading request body: %w", err)
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	recReq := Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Body:   normalize(body),
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(req, recReq)
+	}
+	return r.replay(req, recReq)
+}
+
+func (r *Recorder) record(req *http.Request, recReq Request) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading response body: %w", err)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recReq,
+		Response: Response{
+			Status: resp.StatusCode,
+			Header: resp.Header.Clone(),
+			Body:   normalize(data),
+		},
+	})
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recReq Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// identical requests are answered in the recorded order, the last answer is repeated
+	found := -1
+	for i, in := range r.cassette.Interactions {
+		if in.Request != recReq {
+			continue
+		}
+		found = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if found == -1 {
+		return nil, fmt.Errorf("%w for %s %s %s", ErrNoInteraction, recReq.Method, recReq.Path, recReq.Body)
+	}
+	r.used[found] = true
+
+	in := r.cassette.Interactions[found].Response
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.Status, http.StatusText(in.Status)),
+		StatusCode:    in.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        in.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader([]byte(in.Body))),
+		ContentLength: int64(len(in.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Save writes recorded interactions to the cassette file.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error while marshalling cassette: %w", err)
+	}
+	if err := os.WriteFile(r.path, data, 0o644); err != nil {
+		return fmt.Errorf("error while writing cassette: %w", err)
+	}
+	return nil
+}
+
+// normalize scrubs credentials from a json body and sorts its keys,
+// so bodies differing only in credentials or key order match.
+func normalize(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+
+	data, err := json.Marshal(scrub(v))
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
+
+func scrub(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		for k, val := range vv {
+			if scrubbedKeys[k] {
+				vv[k] = scrubbed
+				continue
+			}
+			vv[k] = scrub(val)
+		}
+	case []interface{}:
+		for i, val := range vv {
+			vv[i] = scrub(val)
+		}
+	}
+	return v
+}
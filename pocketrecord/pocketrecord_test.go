@@ -0,0 +1,89 @@
+package pocketrecord
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	consumerKey = "consumer-key"
+	accessToken = "access-token"
+)
+
+func TestRecorder_RecordReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/get":
+			_, err := w.Write([]byte(`{"status":1,"list":{"1":{"item_id":"1","given_url":"https://go.dev"}}}`))
+			require.NoError(t, err)
+		case "/v3/oauth/authorize":
+			_, err := w.Write([]byte(`{"access_token":"` + accessToken + `","username":"user"}`))
+			require.NoError(t, err)
+		default:
+			w.Header().Add("X-Error-Code", "107")
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	rec, err := New(cassette, ModeRecord)
+	require.NoError(t, err)
+
+	p := pocket.New(consumerKey).WithBaseUrl(srv.URL).WithHttpClient(rec.Client())
+	p.SetAccessToken(accessToken)
+	p.SetRequestToken("request-token")
+
+	res, err := p.Retrieve(context.Background(), &pocket.RetrieveInput{State: pocket.All})
+	require.NoError(t, err)
+	require.Len(t, res.List, 1)
+	_, err = p.GenerateAccessToken(context.Background())
+	require.NoError(t, err)
+	_, err = p.Add(context.Background(), &pocket.AddInput{Url: "https://go.dev"})
+	require.Error(t, err)
+
+	require.NoError(t, rec.Save())
+
+	data, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+	require.False(t, strings.Contains(string(data), consumerKey))
+	require.False(t, strings.Contains(string(data), accessToken))
+
+	rep, err := New(cassette, ModeReplay)
+	require.NoError(t, err)
+	require.Len(t, rep.Interactions(), 3)
+
+	p = pocket.New("other-key").WithBaseUrl("http://pocket.invalid").WithHttpClient(rep.Client())
+	p.SetAccessToken("other-token")
+	p.SetRequestToken("request-token")
+
+	res, err = p.Retrieve(context.Background(), &pocket.RetrieveInput{State: pocket.All})
+	require.NoError(t, err)
+	require.Equal(t, "https://go.dev", res.List["1"].GivenURL)
+
+	_, err = p.Add(context.Background(), &pocket.AddInput{Url: "https://go.dev"})
+	var perr *pocket.ErrorPocket
+	require.True(t, errors.As(err, &perr))
+	require.Equal(t, http.StatusUnauthorized, perr.HttpCode)
+
+	_, err = p.Retrieve(context.Background(), &pocket.RetrieveInput{State: pocket.Unread})
+	require.ErrorIs(t, err, ErrNoInteraction)
+}
+
+func TestNormalize(t *testing.T) {
+	require.Equal(t,
+		normalize([]byte(`{"b":1,"consumer_key":"k1","a":2}`)),
+		normalize([]byte(`{"a":2,"consumer_key":"k2","b":1}`)),
+	)
+	require.Equal(t, "", normalize(nil))
+}
@@ -0,0 +1,362 @@
+// Package query implements a small query language over retrieved items, e.g.
+//
+//	tag:go -tag:read domain:github.com words>1500 added<30d is:favorite
+//
+// Supported terms, every term may be negated with "-":
+//
+//	tag:name           the item has the tag, tag:_untagged_ matches items without tags
+//	domain:host        the item url is on the domain, www. is ignored
+//	type:article       article, video or image; like Pocket's contentType, video and image
+//	                   also match articles with embedded videos or images
+//	is:favorite        favorite, unread or archived
+//	words>N            word count compared with >, <, >=, <= or =
+//	ttr>N              time to read in minutes, same operators
+//	added<30d          added less (or more with >) than the duration ago, units: h, d, w, m (30d), y (365d)
+//	word or "a phrase" the title or the url contains the text, case insensitive
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/store"
+)
+
+type term struct {
+	neg   bool
+	key   string
+	op    string
+	value string
+	match func(item pocket.RetrieveListItem, now time.Time) bool
+}
+
+type Query struct {
+	raw   string
+	terms []term
+}
+
+// Parse parses the query. An empty query matches all items.
+func Parse(s string) (*Query, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{raw: s}
+	for _, tok := range tokens {
+		t, err := parseTerm(tok)
+		if err != nil {
+			return nil, err
+		}
+		q.terms = append(q.terms, t)
+	}
+	return q, nil
+}
+
+func MustParse(s string) *Query {
+	q, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+func (q *Query) String() string {
+	return q.raw
+}
+
+func (q *Query) Match(item pocket.RetrieveListItem) bool {
+	return q.MatchAt(item, time.Now())
+}
+
+// MatchAt matches the item with relative dates counted from now.
+func (q *Query) MatchAt(item pocket.RetrieveListItem, now time.Time) bool {
+	for _, t := range q.terms {
+		if t.match(item, now) == t.neg {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns matching items.
+func (q *Query) Filter(items []pocket.RetrieveListItem) []pocket.RetrieveListItem {
+	now := time.Now()
+	var res []pocket.RetrieveListItem
+	for _, item := range items {
+		if q.MatchAt(item, now) {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// Select returns matching items of the store, using its tag, domain and status indexes when possible.
+func (q *Query) Select(s store.Store) []pocket.RetrieveListItem {
+	for _, t := range q.terms {
+		if t.neg {
+			continue
+		}
+		switch {
+		case t.key == "tag" && t.value != pocket.Untagged:
+			return q.Filter(s.ByTag(t.value))
+		case t.key == "domain":
+			return q.Filter(s.ByDomain(t.value))
+		case t.key == "is" && t.value == "unread":
+			return q.Filter(s.ByStatus(pocket.ItemStatusUnread))
+		case t.key == "is" && t.value == "archived":
+			return q.Filter(s.ByStatus(pocket.ItemStatusArchived))
+		}
+	}
+	return q.Filter(s.All())
+}
+
+// RetrieveInput returns the input selecting a superset of matching items on the server.
+// Terms Pocket can't filter by are left to Match.
+func (q *Query) RetrieveInput() *pocket.RetrieveInput {
+	in := &pocket.RetrieveInput{
+		State: pocket.All,
+	}
+
+	for _, t := range q.terms {
+		if t.neg {
+			continue
+		}
+		switch t.key {
+		case "tag":
+			if in.Tag == "" {
+				in.Tag = t.value
+			}
+		case "domain":
+			if in.Domain == "" {
+				in.Domain = t.value
+			}
+		case "type":
+			in.ContentType = pocket.ContentType(t.value)
+		case "is":
+			switch t.value {
+			case "favorite":
+				fav := pocket.Favorited
+				in.Favorite = &fav
+			case "unread":
+				in.State = pocket.Unread
+			case "archived":
+				in.State = pocket.Archive
+			}
+		case "text":
+			if in.Search == "" {
+				in.Search = t.value
+			}
+		}
+	}
+
+	return in
+}
+
+// BulkQuery returns the query for pocket.Bulk.
+func (q *Query) BulkQuery() pocket.BulkQuery {
+	return pocket.BulkQuery{
+		Input: *q.RetrieveInput(),
+		Match: q.Match,
+	}
+}
+
+func tokenize(s string) ([]string, error) {
+	var (
+		tokens []string
+		cur    strings.Builder
+		quoted bool
+	)
+
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			cur.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if cur.Len() != 0 {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in query %q", s)
+	}
+	if cur.Len() != 0 {
+		tokens = append(tokens, cur.String())
+	}
+
+	return tokens, nil
+}
+
+func parseTerm(tok string) (term, error) {
+	t := term{}
+	if strings.HasPrefix(tok, "-") && len(tok) > 1 {
+		t.neg = true
+		tok = tok[1:]
+	}
+
+	if i := strings.IndexByte(tok, ':'); i > 0 && !strings.HasPrefix(tok, `"`) {
+		t.key, t.op, t.value = strings.ToLower(tok[:i]), ":", unquote(tok[i+1:])
+		return t, t.compileField()
+	}
+
+	for _, key := range []string{"words", "ttr", "added"} {
+		if !strings.HasPrefix(tok, key) {
+			continue
+		}
+		rest := tok[len(key):]
+		for _, op := range []string{">=", "<=", ">", "<", "="} {
+			if strings.HasPrefix(rest, op) {
+				t.key, t.op, t.value = key, op, rest[len(op):]
+				return t, t.compileCompare()
+			}
+		}
+	}
+
+	t.key, t.value = "text", unquote(tok)
+	text := strings.ToLower(t.value)
+	t.match = func(item pocket.RetrieveListItem, _ time.Time) bool {
+		for _, s := range []string{item.ResolvedTitle, item.GivenTitle, item.ResolvedURL, item.GivenURL} {
+			if strings.Contains(strings.ToLower(s), text) {
+				return true
+			}
+		}
+		return false
+	}
+	return t, nil
+}
+
+func (t *term) compileField() error {
+	value := t.value
+	switch t.key {
+	case "tag":
+		t.match = func(item pocket.RetrieveListItem, _ time.Time) bool {
+			if value == pocket.Untagged {
+				return len(item.Tags) == 0
+			}
+			return item.HasTag(value)
+		}
+	case "domain":
+		domain := strings.TrimPrefix(strings.ToLower(value), "www.")
+		t.value = domain
+		t.match = func(item pocket.RetrieveListItem, _ time.Time) bool {
+			return item.Domain() == domain
+		}
+	case "type":
+		switch pocket.ContentType(value) {
+		case pocket.ArticleType:
+			t.match = func(item pocket.RetrieveListItem, _ time.Time) bool { return item.IsArticle == "1" }
+		// has_video and has_image are 1 if the item has videos or images and 2 if it is one,
+		// Pocket returns both for contentType, so a pushed down filter and Select agree
+		case pocket.VideoType:
+			t.match = func(item pocket.RetrieveListItem, _ time.Time) bool {
+				return item.HasVideo == "1" || item.HasVideo == "2"
+			}
+		case pocket.ImageType:
+			t.match = func(item pocket.RetrieveListItem, _ time.Time) bool {
+				return item.HasImage == "1" || item.HasImage == "2"
+			}
+		default:
+			return fmt.Errorf("unknown content type %q", value)
+		}
+	case "is":
+		switch value {
+		case "favorite":
+			t.match = func(item pocket.RetrieveListItem, _ time.Time) bool { return item.IsFavorite() }
+		case "unread":
+			t.match = func(item pocket.RetrieveListItem, _ time.Time) bool { return item.Status == pocket.ItemStatusUnread }
+		case "archived":
+			t.match = func(item pocket.RetrieveListItem, _ time.Time) bool { return item.Status == pocket.ItemStatusArchived }
+		default:
+			return fmt.Errorf("unknown is: value %q", value)
+		}
+	default:
+		return fmt.Errorf("unknown query key %q", t.key)
+	}
+	return nil
+}
+
+func (t *term) compileCompare() error {
+	op := t.op
+	if t.key == "added" {
+		d, err := parseDuration(t.value)
+		if err != nil {
+			return err
+		}
+		// added<30d means the item is newer than 30 days, so the age is compared
+		t.match = func(item pocket.RetrieveListItem, now time.Time) bool {
+			added := item.TimeAddedAt()
+			if added.IsZero() {
+				return false
+			}
+			return compare(int64(now.Sub(added)), op, int64(d))
+		}
+		return nil
+	}
+
+	n, err := strconv.ParseInt(t.value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %q in query: %w", t.value, err)
+	}
+	if t.key == "words" {
+		t.match = func(item pocket.RetrieveListItem, _ time.Time) bool {
+			return compare(int64(item.Words()), op, n)
+		}
+	} else {
+		t.match = func(item pocket.RetrieveListItem, _ time.Time) bool {
+			return compare(int64(item.TimeToRead), op, n)
+		}
+	}
+	return nil
+}
+
+func compare(a int64, op string, b int64) bool {
+	switch op {
+	case ">":
+		return a > b
+	case "<":
+		return a < b
+	case ">=":
+		return a >= b
+	case "<=":
+		return a <= b
+	}
+	return a == b
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	units := map[byte]time.Duration{
+		'h': time.Hour,
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+		'm': 30 * 24 * time.Hour,
+		'y': 365 * 24 * time.Hour,
+	}
+	if len(s) < 2 {
+		return 0, fmt.Errorf("invalid duration %q in query", s)
+	}
+	unit, ok := units[s[len(s)-1]]
+	if !ok {
+		return 0, fmt.Errorf("unknown duration unit in %q", s)
+	}
+	n, err := strconv.ParseInt(s[:len(s)-1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q in query: %w", s, err)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && strings.HasPrefix(s, `"`) && strings.HasSuffix(s, `"`) {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
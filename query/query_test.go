@@ -0,0 +1,151 @@
+package query
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/store"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func daysAgo(n int) string {
+	return strconv.FormatInt(now.Add(-time.Duration(n)*24*time.Hour).Unix(), 10)
+}
+
+func testItems() []pocket.RetrieveListItem {
+	return []pocket.RetrieveListItem{
+		{
+			ItemID:        "1",
+			Status:        pocket.ItemStatusUnread,
+			Favorite:      "1",
+			ResolvedURL:   "https://github.com/golang/go",
+			ResolvedTitle: "The Go Programming Language",
+			WordCount:     "2000",
+			TimeAdded:     daysAgo(10),
+			IsArticle:     "1",
+			HasImage:      "1",
+			Tags:          map[string]pocket.Tag{"go": {Tag: "go"}},
+		},
+		{
+			ItemID:        "2",
+			Status:        pocket.ItemStatusArchived,
+			ResolvedURL:   "https://www.github.com/rust-lang/rust",
+			ResolvedTitle: "Rust",
+			WordCount:     "3000",
+			HasVideo:      "1",
+			TimeAdded:     daysAgo(100),
+			TimeToRead:    15,
+			Tags: map[string]pocket.Tag{
+				"go":   {Tag: "go"},
+				"read": {Tag: "read"},
+			},
+		},
+		{
+			ItemID:        "3",
+			Status:        pocket.ItemStatusUnread,
+			ResolvedURL:   "https://youtube.com/watch?v=1",
+			ResolvedTitle: "Gophercon talk",
+			HasVideo:      "2",
+			TimeAdded:     daysAgo(1),
+		},
+	}
+}
+
+func matched(q *Query) []string {
+	ids := []string{}
+	for _, item := range testItems() {
+		if q.MatchAt(item, now) {
+			ids = append(ids, item.ItemID)
+		}
+	}
+	return ids
+}
+
+func TestQuery_Match(t *testing.T) {
+	tests := []struct {
+		query  string
+		expIDs []string
+	}{
+		{query: "", expIDs: []string{"1", "2", "3"}},
+		{query: "tag:go -tag:read domain:github.com words>1500 added<30d is:favorite", expIDs: []string{"1"}},
+		{query: "tag:go", expIDs: []string{"1", "2"}},
+		{query: "-tag:read", expIDs: []string{"1", "3"}},
+		{query: "tag:_untagged_", expIDs: []string{"3"}},
+		{query: "domain:www.github.com", expIDs: []string{"1", "2"}},
+		{query: "words>=3000", expIDs: []string{"2"}},
+		{query: "ttr>10", expIDs: []string{"2"}},
+		{query: "added>30d", expIDs: []string{"2"}},
+		{query: "added<2d", expIDs: []string{"3"}},
+		{query: "added<1w", expIDs: []string{"3"}},
+		{query: "is:archived", expIDs: []string{"2"}},
+		{query: "-is:archived type:video", expIDs: []string{"3"}},
+		{query: "type:article", expIDs: []string{"1"}},
+		{query: "type:video", expIDs: []string{"2", "3"}},
+		{query: "type:image", expIDs: []string{"1"}},
+		{query: "-type:video", expIDs: []string{"1"}},
+		{query: "gopher", expIDs: []string{"3"}},
+		{query: `"programming language"`, expIDs: []string{"1"}},
+		{query: "RUST -is:unread", expIDs: []string{"2"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := Parse(tc.query)
+			require.NoError(t, err)
+			require.Equal(t, tc.expIDs, matched(q))
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, s := range []string{
+		`"unterminated`,
+		"unknown:key",
+		"is:nothing",
+		"type:audio",
+		"words>many",
+		"added<30x",
+		"added<d",
+	} {
+		_, err := Parse(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestQuery_RetrieveInput(t *testing.T) {
+	in := MustParse("tag:go -tag:read domain:github.com type:article is:favorite is:unread words>100 golang").RetrieveInput()
+
+	require.Equal(t, "go", in.Tag)
+	require.Equal(t, "github.com", in.Domain)
+	require.Equal(t, pocket.ArticleType, in.ContentType)
+	require.Equal(t, pocket.Unread, in.State)
+	require.Equal(t, pocket.Favorited, *in.Favorite)
+	require.Equal(t, "golang", in.Search)
+
+	in = MustParse("-is:unread").RetrieveInput()
+	require.Equal(t, pocket.All, in.State)
+	require.Nil(t, in.Favorite)
+}
+
+func TestQuery_Select(t *testing.T) {
+	s := store.NewMemory()
+	require.NoError(t, s.Put(testItems()...))
+
+	items := MustParse("tag:go -tag:read").Select(s)
+	require.Len(t, items, 1)
+	require.Equal(t, "1", items[0].ItemID)
+
+	items = MustParse("is:unread -is:favorite").Select(s)
+	require.Len(t, items, 1)
+	require.Equal(t, "3", items[0].ItemID)
+
+	items = MustParse("domain:WWW.GitHub.com -is:favorite").Select(s)
+	require.Len(t, items, 1)
+	require.Equal(t, "2", items[0].ItemID)
+
+	require.Empty(t, MustParse("domain:example.com").Select(s))
+}
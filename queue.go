@@ -0,0 +1,319 @@
+package pocket
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// opposite actions cancel each other if they are the first and the last action on an item
+var oppositeActions = map[ActionType]ActionType{
+	ActionFavoriteType:   ActionUnfavoriteType,
+	ActionUnfavoriteType: ActionFavoriteType,
+	ActionArchiveType:    ActionReaddType,
+	ActionReaddType:      ActionArchiveType,
+}
+
+// ActionOutcome is the result of a flushed action taken from action_results.
+type ActionOutcome struct {
+	Action interface{}
+	OK     bool
+	Result interface{} // true, false or the added item for the add action
+	Error  interface{} // the entry of action_errors, if Pocket returned it
+	// the action wasn't sent because of a conflict with the server state, see Reconciler
+	Conflict *Conflict
+}
+
+// ActionQueue keeps actions made offline in a file and sends them with Modify later.
+type ActionQueue struct {
+	// flushMu allows one Flush at a time, so a batch is never sent twice
+	flushMu    sync.Mutex
+	mu         sync.Mutex
+	path       string
+	f          *os.File
+	actions    []ActionFields
+	reconciler *Reconciler
+}
+
+// OpenActionQueue loads the queue from the file, creating the file if it doesn't exist.
+// A partially written last line is dropped, a corrupt line before it is an error and the file is left as is.
+func OpenActionQueue(path string) (*ActionQueue, error) {
+	q := &ActionQueue{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("error while reading queue file: %w", err)
+	}
+
+	var valid int
+	r := bufio.NewReader(bytes.NewReader(data))
+	for n := 1; ; n++ {
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		f := ActionFields{}
+		if err := json.Unmarshal(line, &f); err != nil {
+			return nil, fmt.Errorf("error while unmarshalling line %d of queue file: %w", n, err)
+		}
+		q.actions = append(q.actions, f)
+		valid += len(line)
+	}
+
+	// a last line without a newline was partially written when the process was killed
+	if valid != len(data) {
+		if err := writeFileAtomic(path, data[:valid]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := q.open(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// WithReconciler makes Flush resolve conflicts between queued actions and the server state.
+func (q *ActionQueue) WithReconciler(r *Reconciler) *ActionQueue {
+	q.reconciler = r
+	return q
+}
+
+func (q *ActionQueue) open() error {
+	f, err := os.OpenFile(q.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("error while opening queue file: %w", err)
+	}
+	q.f = f
+	return nil
+}
+
+// Enqueue durably appends actions to the queue. Actions without Time get the current time.
+func (q *ActionQueue) Enqueue(actions ...interface{}) error {
+	fields := make([]ActionFields, 0, len(actions))
+	buf := bytes.Buffer{}
+	enc := json.NewEncoder(&buf)
+	now := time.Now().Unix()
+
+	for _, a := range actions {
+		f, err := ParseAction(a)
+		if err != nil {
+			return err
+		}
+		if f.Time == 0 {
+			f.Time = now
+		}
+		if err := enc.Encode(f); err != nil {
+			return fmt.Errorf("error while marshalling action: %w", err)
+		}
+		fields = append(fields, f)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, err := q.f.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("error while writing queue file: %w", err)
+	}
+	if err := q.f.Sync(); err != nil {
+		return fmt.Errorf("error while syncing queue file: %w", err)
+	}
+	q.actions = append(q.actions, fields...)
+
+	return nil
+}
+
+// Len returns the number of queued actions before coalescing.
+func (q *ActionQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.actions)
+}
+
+// Pending returns the actions Flush would send.
+func (q *ActionQueue) Pending() (Actions, error) {
+	q.mu.Lock()
+	fields := append([]ActionFields(nil), q.actions...)
+	q.mu.Unlock()
+
+	return typedActions(coalesce(fields))
+}
+
+// Flush sends the queued actions with one Modify call. If the call fails the queue is kept,
+// otherwise the actions are removed from the queue and their outcomes are returned.
+// Actions rejected by Pocket are not retried, check ActionOutcome.OK.
+// If the sent actions can't be removed from the file, Flush returns the outcomes with the error; they are
+// not sent again by this queue, but are loaded again by OpenActionQueue, so delivery is at least once.
+// Concurrent calls wait for each other, actions can be enqueued during a flush.
+func (q *ActionQueue) Flush(ctx context.Context, c Client) ([]ActionOutcome, error) {
+	q.flushMu.Lock()
+	defer q.flushMu.Unlock()
+
+	q.mu.Lock()
+	fields := append([]ActionFields(nil), q.actions...)
+	q.mu.Unlock()
+
+	actions, err := typedActions(coalesce(fields))
+	if err != nil {
+		return nil, err
+	}
+
+	var outcomes []ActionOutcome
+	if q.reconciler != nil && len(actions) != 0 {
+		var conflicts []Conflict
+		actions, conflicts, err = q.reconciler.Reconcile(ctx, c, actions)
+		if err != nil {
+			return nil, err
+		}
+		for i := range conflicts {
+			outcomes = append(outcomes, ActionOutcome{Action: conflicts[i].Action, Conflict: &conflicts[i]})
+		}
+	}
+
+	if len(actions) != 0 {
+		res, err := c.Modify(ctx, actions)
+		if err != nil {
+			return nil, err
+		}
+		outcomes = append(newOutcomes(actions, res), outcomes...)
+	}
+
+	return outcomes, q.remove(len(fields))
+}
+
+func newOutcomes(actions Actions, res *ModifyResponse) []ActionOutcome {
+	outcomes := make([]ActionOutcome, len(actions))
+	for i, a := range actions {
+		outcomes[i].Action = a
+		if i < len(res.ActionResult) {
+			r := res.ActionResult[i]
+			outcomes[i].Result = r
+			outcomes[i].OK = r != nil && r != false
+		}
+		if i < len(res.ActionErrors) && res.ActionErrors[i] != nil {
+			outcomes[i].Error = res.ActionErrors[i]
+			outcomes[i].OK = false
+		}
+	}
+	return outcomes
+}
+
+// remove drops the first n actions, actions enqueued during a flush are kept.
+func (q *ActionQueue) remove(n int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	rest := append([]ActionFields(nil), q.actions[n:]...)
+
+	buf := bytes.Buffer{}
+	enc := json.NewEncoder(&buf)
+	for _, f := range rest {
+		if err := enc.Encode(f); err != nil {
+			return fmt.Errorf("error while marshalling action: %w", err)
+		}
+	}
+
+	// sent actions are dropped even if the file can't be rewritten, so they are not sent again by this queue;
+	// the old file stays open for Enqueue until it is replaced
+	q.actions = rest
+	if err := writeFileAtomic(q.path, buf.Bytes()); err != nil {
+		return err
+	}
+
+	if err := q.f.Close(); err != nil {
+		return fmt.Errorf("error while closing queue file: %w", err)
+	}
+	return q.open()
+}
+
+func (q *ActionQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.f.Close()
+}
+
+func typedActions(fields []ActionFields) (Actions, error) {
+	actions := make(Actions, 0, len(fields))
+	for _, f := range fields {
+		a, err := f.Typed()
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, a)
+	}
+	return actions, nil
+}
+
+// coalesce drops redundant actions:
+//   - only the last of favorite/unfavorite and of archive/readd actions on an item is kept,
+//     it is dropped too if the first action of the group was the opposite one
+//   - actions made on an item before it was deleted are dropped
+func coalesce(fields []ActionFields) []ActionFields {
+	type group struct {
+		first ActionType
+		last  int
+	}
+	groupOf := func(t ActionType) ActionType {
+		if t == ActionUnfavoriteType {
+			return ActionFavoriteType
+		}
+		if t == ActionReaddType {
+			return ActionArchiveType
+		}
+		return t
+	}
+
+	groups := map[int64]map[ActionType]*group{}
+	deleted := map[int64]int{}
+	for i, f := range fields {
+		if f.ItemID == 0 {
+			continue
+		}
+		if f.Action == ActionDeleteType {
+			deleted[f.ItemID] = i
+			continue
+		}
+		if _, ok := oppositeActions[f.Action]; !ok {
+			continue
+		}
+
+		if groups[f.ItemID] == nil {
+			groups[f.ItemID] = map[ActionType]*group{}
+		}
+		g, ok := groups[f.ItemID][groupOf(f.Action)]
+		if !ok {
+			g = &group{first: f.Action}
+			groups[f.ItemID][groupOf(f.Action)] = g
+		}
+		g.last = i
+	}
+
+	res := make([]ActionFields, 0, len(fields))
+	for i, f := range fields {
+		if f.ItemID == 0 {
+			res = append(res, f)
+			continue
+		}
+		if di, ok := deleted[f.ItemID]; ok && i < di {
+			continue
+		}
+		if f.Action == ActionDeleteType && deleted[f.ItemID] != i {
+			continue
+		}
+		if g, ok := groups[f.ItemID][groupOf(f.Action)]; ok {
+			if g.last != i || oppositeActions[g.first] == fields[g.last].Action {
+				continue
+			}
+		}
+		res = append(res, f)
+	}
+
+	return res
+}
@@ -0,0 +1,247 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoalesce(t *testing.T) {
+	fields := []ActionFields{
+		{Action: ActionFavoriteType, ItemID: 1},
+		{Action: ActionArchiveType, ItemID: 2},
+		{Action: ActionUnfavoriteType, ItemID: 1},
+		{Action: ActionFavoriteType, ItemID: 3},
+		{Action: ActionUnfavoriteType, ItemID: 3},
+		{Action: ActionFavoriteType, ItemID: 3},
+		{Action: ActionTagsAddType, ItemID: 4, Tags: "go"},
+		{Action: ActionDeleteType, ItemID: 4},
+		{Action: ActionAddType, Url: redirectURL},
+		{Action: ActionArchiveType, ItemID: 2},
+		{Action: ActionTagRenameType, OldTag: "a", NewTag: "b"},
+	}
+
+	require.Equal(t, []ActionFields{
+		{Action: ActionFavoriteType, ItemID: 3},
+		{Action: ActionDeleteType, ItemID: 4},
+		{Action: ActionAddType, Url: redirectURL},
+		{Action: ActionArchiveType, ItemID: 2},
+		{Action: ActionTagRenameType, OldTag: "a", NewTag: "b"},
+	}, coalesce(fields))
+}
+
+func TestActionQueue_Flush(t *testing.T) {
+	var received []ActionFields
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		req := struct {
+			Actions []ActionFields `json:"actions"`
+		}{}
+		require.NoError(t, json.Unmarshal(data, &req))
+		received = req.Actions
+
+		_, err = w.Write([]byte(`{"status":1,"action_results":[true,false],"action_errors":[null,{"message":"Invalid item"}]}`))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	q, err := OpenActionQueue(path)
+	require.NoError(t, err)
+
+	require.NoError(t, q.Enqueue(
+		&ActionFavorite{Action: ActionFavoriteType, ItemID: 1},
+		&ActionUnfavorite{Action: ActionUnfavoriteType, ItemID: 1},
+		&ActionArchive{Action: ActionArchiveType, ItemID: 2, Time: 100},
+	))
+	require.NoError(t, q.Enqueue(&ActionDelete{Action: ActionDeleteType, ItemID: 3}))
+	require.NoError(t, q.Close())
+
+	q, err = OpenActionQueue(path)
+	require.NoError(t, err)
+	require.Equal(t, 4, q.Len())
+
+	pending, err := q.Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+
+	p := New(consumerKey).WithBaseUrl(srv.URL)
+	outcomes, err := q.Flush(context.Background(), p)
+	require.NoError(t, err)
+
+	require.Len(t, received, 2)
+	require.Equal(t, ActionArchiveType, received[0].Action)
+	require.Equal(t, int64(100), received[0].Time)
+	require.Equal(t, ActionDeleteType, received[1].Action)
+	require.NotZero(t, received[1].Time)
+
+	require.Len(t, outcomes, 2)
+	require.True(t, outcomes[0].OK)
+	require.IsType(t, &ActionArchive{}, outcomes[0].Action)
+	require.False(t, outcomes[1].OK)
+	require.NotNil(t, outcomes[1].Error)
+
+	require.Equal(t, 0, q.Len())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Empty(t, data)
+	require.NoError(t, q.Close())
+}
+
+func TestActionQueue_FlushError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	q, err := OpenActionQueue(filepath.Join(t.TempDir(), "queue.jsonl"))
+	require.NoError(t, err)
+	require.NoError(t, q.Enqueue(&ActionArchive{Action: ActionArchiveType, ItemID: 1}))
+
+	_, err = q.Flush(context.Background(), New(consumerKey).WithBaseUrl(srv.URL))
+	require.Error(t, err)
+	require.Equal(t, 1, q.Len())
+	require.NoError(t, q.Close())
+}
+
+func TestActionQueue_ConcurrentFlush(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]ActionFields
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		req := struct {
+			Actions []ActionFields `json:"actions"`
+		}{}
+		require.NoError(t, json.Unmarshal(data, &req))
+		mu.Lock()
+		batches = append(batches, req.Actions)
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+		_, err = w.Write([]byte(`{"status":1,"action_results":[true,true]}`))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	q, err := OpenActionQueue(filepath.Join(t.TempDir(), "queue.jsonl"))
+	require.NoError(t, err)
+	require.NoError(t, q.Enqueue(
+		&ActionArchive{Action: ActionArchiveType, ItemID: 1},
+		&ActionArchive{Action: ActionArchiveType, ItemID: 2},
+	))
+
+	p := New(consumerKey).WithBaseUrl(srv.URL)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := q.Flush(context.Background(), p)
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, batches, 1)
+	require.Len(t, batches[0], 2)
+	require.Equal(t, 0, q.Len())
+	require.NoError(t, q.Close())
+}
+
+func TestActionQueue_RemoveError(t *testing.T) {
+	var received [][]ActionFields
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Actions []ActionFields `json:"actions"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		received = append(received, req.Actions)
+
+		_, err := w.Write([]byte(`{"status":1,"action_results":[true]}`))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+	p := New(consumerKey).WithBaseUrl(srv.URL)
+
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	q, err := OpenActionQueue(path)
+	require.NoError(t, err)
+	require.NoError(t, q.Enqueue(&ActionArchive{Action: ActionArchiveType, ItemID: 1}))
+
+	// the rewrite fails, the outcomes are returned and the queue file must stay usable
+	q.path = filepath.Join(path, "missing", "queue.jsonl")
+	outcomes, err := q.Flush(context.Background(), p)
+	require.Error(t, err)
+	require.Len(t, outcomes, 1)
+	require.Equal(t, 0, q.Len())
+
+	require.NoError(t, q.Enqueue(&ActionDelete{Action: ActionDeleteType, ItemID: 2}))
+
+	// the file still has the sent action, it is loaded again after a restart
+	reopened, err := OpenActionQueue(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, reopened.Len())
+	require.NoError(t, reopened.Close())
+
+	// but this queue doesn't send it twice
+	q.path = path
+	_, err = q.Flush(context.Background(), p)
+	require.NoError(t, err)
+	require.Len(t, received, 2)
+	require.Len(t, received[1], 1)
+	require.Equal(t, ActionDeleteType, received[1][0].Action)
+	require.NoError(t, q.Close())
+
+	q, err = OpenActionQueue(path)
+	require.NoError(t, err)
+	require.Equal(t, 0, q.Len())
+	require.NoError(t, q.Close())
+}
+
+func TestOpenActionQueue_PartialLastLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	data := `{"action":"archive","item_id":1}` + "\n" + `{"action":"archive","item_id":2}` + "\n" + `{"action":"arc`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+
+	q, err := OpenActionQueue(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, q.Len())
+	require.NoError(t, q.Enqueue(&ActionArchive{Action: ActionArchiveType, ItemID: 3}))
+	require.NoError(t, q.Close())
+
+	q, err = OpenActionQueue(path)
+	require.NoError(t, err)
+	require.Equal(t, 3, q.Len())
+	require.NoError(t, q.Close())
+}
+
+func TestOpenActionQueue_CorruptLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	data := `{"action":"archive","item_id":1}` + "\n" +
+		`{"action":"arc` + "\n" +
+		`{"action":"archive","item_id":3}` + "\n" +
+		`{"action":"archive","item_id":4}` + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+
+	_, err := OpenActionQueue(path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line 2 of queue file")
+
+	// queued actions after the corrupt line are not lost
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, data, string(got))
+}
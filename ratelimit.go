@@ -0,0 +1,72 @@
+package pocket
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// see https://getpocket.com/developer/docs/rate-limits
+const (
+	headerUserLimit     = "X-Limit-User-Limit"
+	headerUserRemaining = "X-Limit-User-Remaining"
+	headerUserReset     = "X-Limit-User-Reset"
+	headerKeyLimit      = "X-Limit-Key-Limit"
+	headerKeyRemaining  = "X-Limit-Key-Remaining"
+	headerKeyReset      = "X-Limit-Key-Reset"
+)
+
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Duration // time until the current rate limit window resets
+	UpdatedAt time.Time     // zero if Pocket hasn't reported the limit yet
+}
+
+func (rl RateLimit) Known() bool {
+	return !rl.UpdatedAt.IsZero()
+}
+
+func (rl RateLimit) ResetAt() time.Time {
+	return rl.UpdatedAt.Add(rl.Reset)
+}
+
+// RateLimitState keeps the last rate limit reported by Pocket.
+// It is safe for concurrent use, so one state can be shared by several clients.
+type RateLimitState struct {
+	mu    sync.RWMutex
+	limit RateLimit
+}
+
+func NewRateLimitState() *RateLimitState {
+	return &RateLimitState{}
+}
+
+func (s *RateLimitState) Get() RateLimit {
+	if s == nil {
+		return RateLimit{}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.limit
+}
+
+func (s *RateLimitState) update(h http.Header, limitKey, remainingKey, resetKey string) {
+	if s == nil || h.Get(remainingKey) == "" {
+		return
+	}
+
+	limit, _ := strconv.Atoi(h.Get(limitKey))
+	remaining, _ := strconv.Atoi(h.Get(remainingKey))
+	reset, _ := strconv.Atoi(h.Get(resetKey))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limit = RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     time.Duration(reset) * time.Second,
+		UpdatedAt: time.Now(),
+	}
+}
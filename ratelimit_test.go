@@ -0,0 +1,43 @@
+package pocket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPocket_RateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add(headerUserLimit, "320")
+		w.Header().Add(headerUserRemaining, "319")
+		w.Header().Add(headerUserReset, "3600")
+		w.Header().Add(headerKeyLimit, "10000")
+		w.Header().Add(headerKeyRemaining, "9000")
+		w.Header().Add(headerKeyReset, "60")
+		_, err := w.Write([]byte(`{"status":1}`))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	shared := NewRateLimitState()
+	p := New(consumerKey).WithBaseUrl(srv.URL).WithKeyRateLimit(shared)
+	require.False(t, p.UserRateLimit().Known())
+
+	_, err := p.Retrieve(context.Background(), &RetrieveInput{})
+	require.NoError(t, err)
+
+	ul := p.UserRateLimit()
+	require.True(t, ul.Known())
+	require.Equal(t, 320, ul.Limit)
+	require.Equal(t, 319, ul.Remaining)
+	require.Equal(t, time.Hour, ul.Reset)
+
+	kl := shared.Get()
+	require.Equal(t, 10000, kl.Limit)
+	require.Equal(t, 9000, kl.Remaining)
+	require.Equal(t, kl, p.KeyRateLimit())
+}
@@ -0,0 +1,120 @@
+package pocket
+
+import (
+	"context"
+	"strconv"
+)
+
+type ConflictPolicy int
+
+const (
+	// LastWriterWins drops a local action if the item was changed on the server after the action
+	LastWriterWins ConflictPolicy = iota
+	// ServerWins drops a local action if the item was changed on the server since the oldest queued action
+	ServerWins
+	// ResolveWithCallback lets the resolver of Reconciler decide
+	ResolveWithCallback
+)
+
+// Conflict is a local action on an item changed on the server since the local actions were made.
+type Conflict struct {
+	Action  interface{}
+	Fields  ActionFields
+	Server  RetrieveListItem // server state of the item
+	Deleted bool             // the item was deleted on the server
+}
+
+// ServerIsNewer reports whether the server change happened after the local action.
+func (c Conflict) ServerIsNewer() bool {
+	updated, _ := strconv.ParseInt(c.Server.TimeUpdated, 10, 64)
+	return (c.Deleted && updated == 0) || updated >= c.Fields.Time
+}
+
+// Resolver returns the action to send instead of the conflicting one, nil drops the action.
+type Resolver func(c Conflict) interface{}
+
+// Reconciler compares local actions with the server state before they are sent.
+// Actions on deleted items are dropped unless the resolver decides otherwise.
+type Reconciler struct {
+	policy  ConflictPolicy
+	resolve Resolver
+}
+
+func NewReconciler(policy ConflictPolicy) *Reconciler {
+	return &Reconciler{policy: policy}
+}
+
+// WithResolver sets the resolver and the ResolveWithCallback policy.
+func (r *Reconciler) WithResolver(fn Resolver) *Reconciler {
+	r.policy = ResolveWithCallback
+	r.resolve = fn
+	return r
+}
+
+// Reconcile pulls items changed since the oldest action and resolves conflicts.
+// It returns the actions to send and the conflicts dropped.
+func (r *Reconciler) Reconcile(ctx context.Context, c Client, actions Actions) (Actions, []Conflict, error) {
+	fields := make([]ActionFields, len(actions))
+	var since int64
+	for i, a := range actions {
+		f, err := ParseAction(a)
+		if err != nil {
+			return nil, nil, err
+		}
+		fields[i] = f
+		if f.ItemID != 0 && f.Time != 0 && (since == 0 || f.Time < since) {
+			since = f.Time
+		}
+	}
+	if since == 0 {
+		return actions, nil, nil
+	}
+
+	res, err := c.Retrieve(ctx, &RetrieveInput{
+		State:      All,
+		DetailType: Simple,
+		Since:      &since,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kept := make(Actions, 0, len(actions))
+	var dropped []Conflict
+	for i, a := range actions {
+		f := fields[i]
+		server, ok := res.List[strconv.FormatInt(f.ItemID, 10)]
+		if f.ItemID == 0 || !ok {
+			kept = append(kept, a)
+			continue
+		}
+
+		conflict := Conflict{
+			Action:  a,
+			Fields:  f,
+			Server:  server,
+			Deleted: server.Status == ItemStatusDeleted,
+		}
+		if resolved := r.resolveConflict(conflict); resolved != nil {
+			kept = append(kept, resolved)
+		} else {
+			dropped = append(dropped, conflict)
+		}
+	}
+
+	return kept, dropped, nil
+}
+
+func (r *Reconciler) resolveConflict(c Conflict) interface{} {
+	switch {
+	case r.policy == ResolveWithCallback && r.resolve != nil:
+		return r.resolve(c)
+	case c.Deleted:
+		return nil
+	case r.policy == ServerWins:
+		return nil
+	case c.ServerIsNewer():
+		return nil
+	}
+	return c.Action
+}
@@ -0,0 +1,174 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func reconcileHandler(t *testing.T, modified *[]ActionFields) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		if r.URL.Path == modifyPath {
+			req := struct {
+				Actions []ActionFields `json:"actions"`
+			}{}
+			require.NoError(t, json.Unmarshal(data, &req))
+			*modified = req.Actions
+			_, err = w.Write([]byte(`{"status":1,"action_results":[true,true,true]}`))
+			require.NoError(t, err)
+			return
+		}
+
+		req := retrieveRequest{}
+		require.NoError(t, json.Unmarshal(data, &req))
+		require.Equal(t, int64(100), *req.Since)
+		require.Equal(t, All, req.State)
+
+		_, err = w.Write([]byte(`{"status":1,"list":{
+			"1":{"item_id":"1","status":"0","time_updated":"200"},
+			"2":{"item_id":"2","status":"0","time_updated":"150"},
+			"3":{"item_id":"3","status":"2"}}}`))
+		require.NoError(t, err)
+	}
+}
+
+func reconcileActions() Actions {
+	return Actions{
+		&ActionArchive{Action: ActionArchiveType, ItemID: 1, Time: 100},
+		&ActionFavorite{Action: ActionFavoriteType, ItemID: 2, Time: 180},
+		&ActionArchive{Action: ActionArchiveType, ItemID: 3, Time: 120},
+		&ActionArchive{Action: ActionArchiveType, ItemID: 4, Time: 130},
+		&ActionTagRename{Action: ActionTagRenameType, OldTag: "a", NewTag: "b"},
+	}
+}
+
+func actionItemIDs(t *testing.T, actions Actions) []int64 {
+	ids := []int64{}
+	for _, a := range actions {
+		f, err := ParseAction(a)
+		require.NoError(t, err)
+		ids = append(ids, f.ItemID)
+	}
+	return ids
+}
+
+func TestReconciler_Reconcile(t *testing.T) {
+	srv := httptest.NewServer(reconcileHandler(t, nil))
+	defer srv.Close()
+	p := New(consumerKey).WithBaseUrl(srv.URL)
+
+	tests := []struct {
+		name       string
+		reconciler *Reconciler
+		expKept    []int64
+		expDropped int
+	}{
+		{
+			name:       "Last writer wins",
+			reconciler: NewReconciler(LastWriterWins),
+			expKept:    []int64{2, 4, 0},
+			expDropped: 2,
+		},
+		{
+			name:       "Server wins",
+			reconciler: NewReconciler(ServerWins),
+			expKept:    []int64{4, 0},
+			expDropped: 3,
+		},
+		{
+			name: "Callback",
+			reconciler: NewReconciler(ServerWins).WithResolver(func(c Conflict) interface{} {
+				if c.Deleted {
+					return &ActionAdd{Action: ActionAddType, Url: redirectURL}
+				}
+				return c.Action
+			}),
+			expKept:    []int64{1, 2, 0, 4, 0},
+			expDropped: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kept, dropped, err := tc.reconciler.Reconcile(context.Background(), p, reconcileActions())
+			require.NoError(t, err)
+			require.Equal(t, tc.expKept, actionItemIDs(t, kept))
+			require.Len(t, dropped, tc.expDropped)
+		})
+	}
+}
+
+func TestActionQueue_FlushReconcile(t *testing.T) {
+	var modified []ActionFields
+	srv := httptest.NewServer(reconcileHandler(t, &modified))
+	defer srv.Close()
+
+	q, err := OpenActionQueue(filepath.Join(t.TempDir(), "queue.jsonl"))
+	require.NoError(t, err)
+	q.WithReconciler(NewReconciler(LastWriterWins))
+	require.NoError(t, q.Enqueue(reconcileActions()...))
+
+	outcomes, err := q.Flush(context.Background(), New(consumerKey).WithBaseUrl(srv.URL))
+	require.NoError(t, err)
+
+	require.Len(t, modified, 3)
+	require.Len(t, outcomes, 5)
+	require.True(t, outcomes[0].OK)
+	require.NotNil(t, outcomes[3].Conflict)
+	require.False(t, outcomes[3].OK)
+	require.True(t, outcomes[4].Conflict.Deleted)
+	require.NoError(t, q.Close())
+}
+
+func TestActionQueue_FlushReconcileNoChanges(t *testing.T) {
+	var modified []ActionFields
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		if r.URL.Path == modifyPath {
+			req := struct {
+				Actions []ActionFields `json:"actions"`
+			}{}
+			require.NoError(t, json.Unmarshal(data, &req))
+			modified = req.Actions
+			_, err = w.Write([]byte(`{"status":1,"action_results":[true,true]}`))
+			require.NoError(t, err)
+			return
+		}
+
+		// nothing changed on the server since the queued actions
+		_, err = w.Write([]byte(`{"status":2,"since":300,"list":[]}`))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	q, err := OpenActionQueue(filepath.Join(t.TempDir(), "queue.jsonl"))
+	require.NoError(t, err)
+	q.WithReconciler(NewReconciler(ServerWins))
+	require.NoError(t, q.Enqueue(
+		&ActionArchive{Action: ActionArchiveType, ItemID: 1, Time: 100},
+		&ActionFavorite{Action: ActionFavoriteType, ItemID: 2, Time: 180},
+	))
+
+	outcomes, err := q.Flush(context.Background(), New(consumerKey).WithBaseUrl(srv.URL))
+	require.NoError(t, err)
+
+	require.Len(t, modified, 2)
+	require.Len(t, outcomes, 2)
+	for _, o := range outcomes {
+		require.True(t, o.OK)
+		require.Nil(t, o.Conflict)
+	}
+	require.Equal(t, 0, q.Len())
+	require.NoError(t, q.Close())
+}
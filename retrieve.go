@@ -1,9 +1,15 @@
 package pocket
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
 )
 
+const retrievePageSize = 100
+
 // state
 
 type State string
@@ -23,6 +29,14 @@ const (
 	Favorited   Favorite = 1
 )
 
+// status of a retrieved item
+
+const (
+	ItemStatusUnread   = "0"
+	ItemStatusArchived = "1"
+	ItemStatusDeleted  = "2"
+)
+
 //tag
 
 const (
@@ -112,6 +126,21 @@ type Video struct {
 	Length  string `json:"length"`
 }
 
+type Tag struct {
+	ItemID string `json:"item_id"`
+	Tag    string `json:"tag"`
+}
+
+// Annotation is a highlight made in the Pocket reader.
+type Annotation struct {
+	AnnotationID string      `json:"annotation_id"`
+	ItemID       string      `json:"item_id"`
+	Quote        string      `json:"quote"`
+	Patch        string      `json:"patch"`
+	Version      json.Number `json:"version"`
+	CreatedAt    string      `json:"created_at"` // e.g. 2020-07-17 09:00:07
+}
+
 type RetrieveListItem struct {
 	ItemID                 string                `json:"item_id"`
 	ResolvedID             string                `json:"resolved_id"`
@@ -138,7 +167,10 @@ type RetrieveListItem struct {
 	Images                 map[string]ImagesItem `json:"images"`
 	Videos                 map[string]Video      `json:"videos"`
 	DomainMetadata         DomainMetadata        `json:"domain_metadata"`
+	Tags                   map[string]Tag        `json:"tags"`
+	TimeToRead             int                   `json:"time_to_read"`
 	ListenDurationEstimate int                   `json:"listen_duration_estimate"`
+	Annotations            []Annotation          `json:"annotations"`
 }
 
 type RetrieveResponse struct {
@@ -149,13 +181,48 @@ type RetrieveResponse struct {
 	List       map[string]RetrieveListItem `json:"list"`
 }
 
+// UnmarshalJSON accepts the list Pocket sends as an empty array when there are no items.
+func (r *RetrieveResponse) UnmarshalJSON(data []byte) error {
+	type response RetrieveResponse
+	aux := struct {
+		*response
+		List json.RawMessage `json:"list"`
+	}{response: (*response)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	list := bytes.TrimSpace(aux.List)
+	switch {
+	case len(list) == 0 || bytes.Equal(list, []byte("null")):
+		r.List = nil
+	case list[0] == '[':
+		var items []RetrieveListItem
+		if err := json.Unmarshal(list, &items); err != nil {
+			return err
+		}
+		if len(items) != 0 {
+			return fmt.Errorf("list is an array of %d items, expected an object", len(items))
+		}
+		r.List = map[string]RetrieveListItem{}
+	default:
+		r.List = nil
+		return json.Unmarshal(list, &r.List)
+	}
+	return nil
+}
+
 type retrieveRequest struct {
 	*RetrieveInput
 	ConsumerKey string `json:"consumer_key"`
 	AccessToken string `json:"access_token"`
 }
 
-func (p *Pocket) Retrieve(ctx context.Context, rd *RetrieveInput) (*RetrieveResponse, error) {
+func (p *Pocket) Retrieve(ctx context.Context, rd *RetrieveInput) (_ *RetrieveResponse, err error) {
+	attrs := SpanAttrs{}
+	ctx, end := p.startSpan(ctx, SpanRetrieve, attrs)
+	defer func() { end(err) }()
+
 	req := retrieveRequest{
 		RetrieveInput: rd,
 		ConsumerKey:   p.consumerKey,
@@ -163,9 +230,46 @@ func (p *Pocket) Retrieve(ctx context.Context, rd *RetrieveInput) (*RetrieveResp
 	}
 
 	res := RetrieveResponse{}
-	err := p.doRequest(ctx, retrievePath, req, &res)
+	err = p.doRequest(ctx, retrievePath, req, &res)
 	if err != nil {
 		return nil, err
 	}
+	attrs[AttrItemCount] = len(res.List)
+
 	return &res, nil
 }
+
+// RetrieveAll returns items sorted by sort_id. If rd.Count is zero, it retrieves
+// all the items page by page, otherwise it makes only one call.
+func RetrieveAll(ctx context.Context, c Client, rd *RetrieveInput) ([]RetrieveListItem, error) {
+	in := *rd
+	paged := in.Count == 0
+	if paged {
+		in.Count = retrievePageSize
+	}
+
+	var items []RetrieveListItem
+	for {
+		res, err := c.Retrieve(ctx, &in)
+		if err != nil {
+			return nil, err
+		}
+
+		page := make([]RetrieveListItem, 0, len(res.List))
+		for id, item := range res.List {
+			if item.ItemID == "" {
+				item.ItemID = id
+			}
+			page = append(page, item)
+		}
+		sort.Slice(page, func(i, j int) bool {
+			return page[i].SortID < page[j].SortID
+		})
+		items = append(items, page...)
+
+		if !paged || int64(len(res.List)) < in.Count {
+			return items, nil
+		}
+		in.Offset += in.Count
+	}
+}
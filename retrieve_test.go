@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -102,3 +103,88 @@ func TestPocket_Retrieve(t *testing.T) {
 		})
 	}
 }
+
+func TestRetrieveResponse_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		list map[string]RetrieveListItem
+		err  bool
+	}{
+		{"object", `{"status":1,"list":{"1":{"item_id":"1"}}}`, map[string]RetrieveListItem{"1": {ItemID: "1"}}, false},
+		{"empty array", `{"status":2,"list":[]}`, map[string]RetrieveListItem{}, false},
+		{"no list", `{"status":1}`, nil, false},
+		{"null", `{"status":1,"list":null}`, nil, false},
+		{"array of items", `{"status":1,"list":[{"item_id":"1"}]}`, nil, true},
+		{"string", `{"status":1,"list":"x"}`, nil, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := RetrieveResponse{}
+			err := json.Unmarshal([]byte(tc.data), &res)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.list, res.List)
+			require.NotZero(t, res.Status)
+		})
+	}
+}
+
+func TestRetrieveAll(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int64
+		offsets []int64
+	}{
+		{"last page is short", 250, []int64{0, 100, 200}},
+		{"last page is empty", 200, []int64{0, 100, 200}},
+		{"no items", 0, []int64{0}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var offsets []int64
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				data, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				req := retrieveRequest{}
+				require.NoError(t, json.Unmarshal(data, &req))
+				require.Equal(t, int64(retrievePageSize), req.Count)
+				offsets = append(offsets, req.Offset)
+
+				if req.Offset >= tc.total {
+					// Pocket sends an empty array instead of an empty object
+					_, err = w.Write([]byte(`{"status":2,"list":[]}`))
+					require.NoError(t, err)
+					return
+				}
+
+				list := map[string]RetrieveListItem{}
+				for i := req.Offset; i < req.Offset+req.Count && i < tc.total; i++ {
+					list[fmt.Sprint(i)] = RetrieveListItem{SortID: int(i)}
+				}
+				data, err = json.Marshal(&RetrieveResponse{Status: successStatus, List: list})
+				require.NoError(t, err)
+				_, err = w.Write(data)
+				require.NoError(t, err)
+			}))
+			defer srv.Close()
+
+			p := New(consumerKey).WithBaseUrl(srv.URL)
+			items, err := RetrieveAll(context.Background(), p, &RetrieveInput{State: All})
+			require.NoError(t, err)
+
+			require.Equal(t, tc.offsets, offsets)
+			require.Len(t, items, int(tc.total))
+			for i, item := range items {
+				require.Equal(t, i, item.SortID)
+				require.Equal(t, fmt.Sprint(i), item.ItemID)
+			}
+		})
+	}
+}
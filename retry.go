@@ -0,0 +1,17 @@
+package pocket
+
+import "net/http"
+
+// canRetry reports whether a request which failed with the status may be sent again, status is 0 if
+// no complete response was read. Retrieve only reads and is retried after network errors and 429/5xx
+// statuses. Other requests change the list or issue tokens, so they are retried only on 429 and 503
+// which Pocket returns without processing the request; otherwise an action could be applied twice.
+func canRetry(pocketPath string, status int) bool {
+	if status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable {
+		return true
+	}
+	if pocketPath != retrievePath {
+		return false
+	}
+	return status == 0 || status >= http.StatusInternalServerError
+}
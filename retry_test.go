@@ -0,0 +1,76 @@
+package pocket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPocket_Retry(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		path   string
+		calls  int
+	}{
+		{"add gives up on 401", http.StatusUnauthorized, addPath, 1},
+		{"add retries 429", http.StatusTooManyRequests, addPath, 3},
+		{"add retries 503", http.StatusServiceUnavailable, addPath, 3},
+		{"add doesn't retry 500", http.StatusInternalServerError, addPath, 1},
+		{"modify doesn't retry 502", http.StatusBadGateway, modifyPath, 1},
+		{"retrieve retries 500", http.StatusInternalServerError, retrievePath, 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			p := New(consumerKey).WithBaseUrl(srv.URL).WithRetry(3, time.Millisecond)
+			p.SetAccessToken(accessToken)
+
+			var err error
+			switch tc.path {
+			case addPath:
+				_, err = p.Add(context.Background(), &AddInput{Url: redirectURL})
+			case modifyPath:
+				_, err = p.Modify(context.Background(), Actions{})
+			case retrievePath:
+				_, err = p.Retrieve(context.Background(), &RetrieveInput{})
+			}
+			require.Error(t, err)
+			require.Equal(t, tc.calls, calls)
+		})
+	}
+}
+
+func TestPocket_RetryNetworkError(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		// drop the connection without a response
+		conn, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		conn.Close()
+	}))
+	defer srv.Close()
+
+	p := New(consumerKey).WithBaseUrl(srv.URL).WithRetry(3, time.Millisecond)
+
+	_, err := p.Add(context.Background(), &AddInput{Url: redirectURL})
+	require.Error(t, err)
+	require.Equal(t, 1, calls)
+
+	calls = 0
+	_, err = p.Retrieve(context.Background(), &RetrieveInput{})
+	require.Error(t, err)
+	require.Equal(t, 3, calls)
+}
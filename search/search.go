@@ -0,0 +1,341 @@
+// Package search is an in-process full-text index over retrieved items.
+//
+// Queries are whitespace separated clauses, all of them must match:
+//
+//	golang           the word
+//	gorout*          any word with the prefix
+//	"error handling" the phrase
+//
+// Results are ranked by TF-IDF, words of titles and tags weigh more than words of
+// excerpts, urls and author names.
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+// field weights
+const (
+	titleWeight   = 3
+	tagWeight     = 2
+	excerptWeight = 1
+	urlWeight     = 1
+	authorWeight  = 1
+)
+
+// fieldGap separates positions of fields, so phrases don't span two fields
+const fieldGap = 1000
+
+type posting struct {
+	weight    float64
+	positions []int
+}
+
+type Result struct {
+	ItemID string
+	Score  float64
+}
+
+// Index is safe for concurrent use.
+type Index struct {
+	mu       sync.RWMutex
+	postings map[string]map[string]*posting // term -> item id -> posting
+	docs     map[string][]string            // item id -> terms, for removal
+	terms    []string                       // sorted terms for prefix queries, nil if outdated
+}
+
+func New() *Index {
+	return &Index{
+		postings: make(map[string]map[string]*posting),
+		docs:     make(map[string][]string),
+	}
+}
+
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.docs)
+}
+
+// Add indexes items, replacing already indexed items with the same id.
+func (ix *Index) Add(items ...pocket.RetrieveListItem) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	for _, item := range items {
+		ix.add(item)
+	}
+}
+
+func (ix *Index) Remove(ids ...string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	for _, id := range ids {
+		ix.remove(id)
+	}
+}
+
+// ApplyDelta updates the index with changes pulled by pocket.Syncer.
+func (ix *Index) ApplyDelta(d *pocket.Delta) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	if d.Full {
+		for id := range ix.docs {
+			ix.remove(id)
+		}
+	}
+	for _, items := range [][]pocket.RetrieveListItem{d.Added, d.Updated, d.Archived} {
+		for _, item := range items {
+			ix.add(item)
+		}
+	}
+	for _, item := range d.Deleted {
+		ix.remove(item.ItemID)
+	}
+}
+
+func (ix *Index) add(item pocket.RetrieveListItem) {
+	ix.remove(item.ItemID)
+
+	title := item.ResolvedTitle
+	if title == "" {
+		title = item.GivenTitle
+	}
+	u := item.ResolvedURL
+	if u == "" {
+		u = item.GivenURL
+	}
+	authors := make([]string, 0, len(item.Authors))
+	for _, a := range item.Authors {
+		authors = append(authors, a.Name)
+	}
+
+	fields := []struct {
+		text   string
+		weight float64
+	}{
+		{title, titleWeight},
+		{strings.Join(item.TagNames(), " "), tagWeight},
+		{item.Excerpt, excerptWeight},
+		{u, urlWeight},
+		{strings.Join(authors, " "), authorWeight},
+	}
+
+	doc := map[string]*posting{}
+	for i, f := range fields {
+		for pos, tok := range tokenize(f.text) {
+			p, ok := doc[tok]
+			if !ok {
+				p = &posting{}
+				doc[tok] = p
+			}
+			p.weight += f.weight
+			p.positions = append(p.positions, i*fieldGap+pos)
+		}
+	}
+
+	terms := make([]string, 0, len(doc))
+	for term, p := range doc {
+		docs, ok := ix.postings[term]
+		if !ok {
+			docs = make(map[string]*posting)
+			ix.postings[term] = docs
+			ix.terms = nil
+		}
+		docs[item.ItemID] = p
+		terms = append(terms, term)
+	}
+	ix.docs[item.ItemID] = terms
+}
+
+func (ix *Index) remove(id string) {
+	terms, ok := ix.docs[id]
+	if !ok {
+		return
+	}
+	for _, term := range terms {
+		delete(ix.postings[term], id)
+		if len(ix.postings[term]) == 0 {
+			delete(ix.postings, term)
+			ix.terms = nil
+		}
+	}
+	delete(ix.docs, id)
+}
+
+// Search returns at most limit items matching every clause of the query, the best first.
+// limit <= 0 returns all matching items.
+func (ix *Index) Search(query string, limit int) []Result {
+	clauses := parseQuery(query)
+	if len(clauses) == 0 {
+		return nil
+	}
+
+	ix.mu.RLock()
+	for ix.terms == nil {
+		ix.mu.RUnlock()
+		ix.mu.Lock()
+		ix.sortTerms()
+		ix.mu.Unlock()
+		ix.mu.RLock()
+	}
+	defer ix.mu.RUnlock()
+
+	var scores map[string]float64
+	for _, c := range clauses {
+		cs := ix.scoreClause(c)
+		if scores == nil {
+			scores = cs
+			continue
+		}
+		for id, s := range scores {
+			if add, ok := cs[id]; ok {
+				scores[id] = s + add
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+
+	res := make([]Result, 0, len(scores))
+	for id, s := range scores {
+		res = append(res, Result{ItemID: id, Score: s})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Score != res[j].Score {
+			return res[i].Score > res[j].Score
+		}
+		return res[i].ItemID < res[j].ItemID
+	})
+	if limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res
+}
+
+func (ix *Index) sortTerms() {
+	if ix.terms != nil {
+		return
+	}
+	ix.terms = make([]string, 0, len(ix.postings))
+	for term := range ix.postings {
+		ix.terms = append(ix.terms, term)
+	}
+	sort.Strings(ix.terms)
+}
+
+type clause struct {
+	words  []string // more than one word for phrases
+	prefix bool
+}
+
+func (ix *Index) idf(term string) float64 {
+	return math.Log(1 + float64(len(ix.docs))/float64(len(ix.postings[term])))
+}
+
+func (ix *Index) scoreClause(c clause) map[string]float64 {
+	scores := map[string]float64{}
+
+	switch {
+	case c.prefix:
+		word := c.words[0]
+		for i := sort.SearchStrings(ix.terms, word); i < len(ix.terms) && strings.HasPrefix(ix.terms[i], word); i++ {
+			term := ix.terms[i]
+			idf := ix.idf(term)
+			for id, p := range ix.postings[term] {
+				scores[id] += p.weight * idf
+			}
+		}
+	case len(c.words) == 1:
+		term := c.words[0]
+		idf := ix.idf(term)
+		for id, p := range ix.postings[term] {
+			scores[id] = p.weight * idf
+		}
+	default:
+		for id, first := range ix.postings[c.words[0]] {
+			n := ix.phraseCount(id, first, c.words[1:])
+			if n == 0 {
+				continue
+			}
+			idf := 0.0
+			for _, w := range c.words {
+				idf += ix.idf(w)
+			}
+			scores[id] = float64(n) * idf
+		}
+	}
+
+	return scores
+}
+
+// phraseCount returns how many times the words follow the first word in the item.
+func (ix *Index) phraseCount(id string, first *posting, rest []string) int {
+	next := make([]*posting, len(rest))
+	for i, w := range rest {
+		p, ok := ix.postings[w][id]
+		if !ok {
+			return 0
+		}
+		next[i] = p
+	}
+
+	n := 0
+	for _, pos := range first.positions {
+		found := true
+		for i, p := range next {
+			if !hasPosition(p.positions, pos+i+1) {
+				found = false
+				break
+			}
+		}
+		if found {
+			n++
+		}
+	}
+	return n
+}
+
+func hasPosition(positions []int, pos int) bool {
+	i := sort.SearchInts(positions, pos)
+	return i < len(positions) && positions[i] == pos
+}
+
+func parseQuery(q string) []clause {
+	var clauses []clause
+
+	parts := strings.Split(q, `"`)
+	for i, part := range parts {
+		// odd parts are inside quotes
+		if i%2 == 1 {
+			if words := tokenize(part); len(words) != 0 {
+				clauses = append(clauses, clause{words: words})
+			}
+			continue
+		}
+		for _, field := range strings.Fields(part) {
+			words := tokenize(field)
+			for j, w := range words {
+				clauses = append(clauses, clause{
+					words:  []string{w},
+					prefix: j == len(words)-1 && strings.HasSuffix(field, "*"),
+				})
+			}
+		}
+	}
+
+	return clauses
+}
+
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,92 @@
+package search
+
+import (
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func testItems() []pocket.RetrieveListItem {
+	return []pocket.RetrieveListItem{
+		{
+			ItemID:        "1",
+			ResolvedTitle: "Error handling in Go",
+			Excerpt:       "Go code uses error values to indicate an abnormal state.",
+			ResolvedURL:   "https://go.dev/blog/error-handling-and-go",
+			Authors:       map[string]pocket.Author{"1": {Name: "Andrew Gerrand"}},
+		},
+		{
+			ItemID:        "2",
+			ResolvedTitle: "Goroutines and channels",
+			Excerpt:       "Concurrency patterns, handling errors in goroutines.",
+			ResolvedURL:   "https://example.com/concurrency",
+			Tags:          map[string]pocket.Tag{"golang": {Tag: "golang"}},
+		},
+		{
+			ItemID:        "3",
+			ResolvedTitle: "Rust error handling",
+			ResolvedURL:   "https://doc.rust-lang.org/book/ch09-00-error-handling.html",
+		},
+	}
+}
+
+func ids(res []Result) []string {
+	ids := []string{}
+	for _, r := range res {
+		ids = append(ids, r.ItemID)
+	}
+	return ids
+}
+
+func TestIndex_Search(t *testing.T) {
+	ix := New()
+	ix.Add(testItems()...)
+	require.Equal(t, 3, ix.Len())
+
+	tests := []struct {
+		query  string
+		expIDs []string
+	}{
+		{query: "error", expIDs: []string{"1", "3"}},
+		{query: "ERROR go", expIDs: []string{"1"}},
+		{query: "gorout*", expIDs: []string{"2"}},
+		{query: "go*", expIDs: []string{"1", "2"}},
+		{query: `"error handling"`, expIDs: []string{"1", "3"}},
+		{query: `"handling error"`, expIDs: []string{}},
+		{query: `"handling errors" golang`, expIDs: []string{"2"}},
+		{query: "gerrand", expIDs: []string{"1"}},
+		{query: "rust-lang", expIDs: []string{"3"}},
+		{query: "missing", expIDs: []string{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			require.Equal(t, tc.expIDs, ids(ix.Search(tc.query, 0)))
+		})
+	}
+
+	require.Len(t, ix.Search("error", 1), 1)
+	require.Empty(t, ix.Search("", 0))
+}
+
+func TestIndex_Updates(t *testing.T) {
+	ix := New()
+	ix.ApplyDelta(&pocket.Delta{Full: true, Added: testItems()})
+	require.Equal(t, []string{"2"}, ids(ix.Search("goroutines", 0)))
+
+	updated := testItems()[1]
+	updated.ResolvedTitle = "Channels"
+	updated.Excerpt = ""
+	ix.ApplyDelta(&pocket.Delta{
+		Updated: []pocket.RetrieveListItem{updated},
+		Deleted: []pocket.RetrieveListItem{{ItemID: "3"}},
+	})
+	require.Empty(t, ix.Search("goroutines", 0))
+	require.Empty(t, ix.Search("gorout*", 0))
+	require.Equal(t, []string{"1"}, ids(ix.Search("error", 0)))
+	require.Equal(t, 2, ix.Len())
+
+	ix.Remove("1")
+	require.Empty(t, ix.Search("error", 0))
+}
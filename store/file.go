@@ -0,0 +1,265 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+const (
+	opPut    = "put"
+	opDelete = "delete"
+)
+
+// record is a put or delete of an item, a line of the file.
+type record struct {
+	Op   string                   `json:"op"`
+	ID   string                   `json:"id,omitempty"`
+	Item *pocket.RetrieveListItem `json:"item,omitempty"`
+}
+
+// File is a Store keeping items in memory and every change in a JSON-lines file.
+// The file is replayed on open, Compact rewrites it with only the current items.
+// A partially written last line is dropped on open, a corrupt line before it fails Open.
+// Changes are applied in memory only after they were written, so a failed write changes nothing.
+type File struct {
+	mem *Memory
+
+	mu      sync.Mutex
+	path    string
+	f       *os.File
+	w       *bufio.Writer
+	records int
+}
+
+var _ Store = (*File)(nil)
+
+// Open loads the store from the file, creating the file if it doesn't exist.
+func Open(path string) (*File, error) {
+	s := &File{
+		mem:  NewMemory(),
+		path: path,
+	}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("error while opening store file: %w", err)
+	}
+	s.f = f
+	s.w = bufio.NewWriter(f)
+
+	return s, nil
+}
+
+func (s *File) load() error {
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error while opening store file: %w", err)
+	}
+	defer f.Close()
+
+	var valid int64
+	r := bufio.NewReader(f)
+	for n := 1; ; n++ {
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// a last line without a newline was partially written when the process was killed
+			if len(line) != 0 {
+				if err := os.Truncate(s.path, valid); err != nil {
+					return fmt.Errorf("error while truncating store file: %w", err)
+				}
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error while reading store file: %w", err)
+		}
+
+		rec := record{}
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return fmt.Errorf("error while unmarshalling line %d of store file: %w", n, err)
+		}
+		s.mem.apply([]record{rec})
+		s.records++
+		valid += int64(len(line))
+	}
+}
+
+func (s *File) Get(id string) (pocket.RetrieveListItem, bool) {
+	return s.mem.Get(id)
+}
+
+func (s *File) All() []pocket.RetrieveListItem {
+	return s.mem.All()
+}
+
+func (s *File) ByTag(tag string) []pocket.RetrieveListItem {
+	return s.mem.ByTag(tag)
+}
+
+func (s *File) ByDomain(domain string) []pocket.RetrieveListItem {
+	return s.mem.ByDomain(domain)
+}
+
+func (s *File) ByStatus(status string) []pocket.RetrieveListItem {
+	return s.mem.ByStatus(status)
+}
+
+func (s *File) Len() int {
+	return s.mem.Len()
+}
+
+func (s *File) Put(items ...pocket.RetrieveListItem) error {
+	recs := make([]record, len(items))
+	for i := range items {
+		recs[i] = record{Op: opPut, Item: &items[i]}
+	}
+	return s.commit(recs, nil)
+}
+
+func (s *File) Delete(ids ...string) error {
+	recs := make([]record, len(ids))
+	for i, id := range ids {
+		recs[i] = record{Op: opDelete, ID: id}
+	}
+	return s.commit(recs, nil)
+}
+
+func (s *File) ApplyDelta(d *pocket.Delta) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mem.mu.RLock()
+	recs := s.mem.deltaRecords(d)
+	s.mem.mu.RUnlock()
+	return s.commitLocked(recs, nil)
+}
+
+func (s *File) ApplyActions(actions pocket.Actions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mem.mu.RLock()
+	recs, err := s.mem.actionRecords(actions)
+	s.mem.mu.RUnlock()
+	return s.commitLocked(recs, err)
+}
+
+func (s *File) commit(recs []record, err error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.commitLocked(recs, err)
+}
+
+// commitLocked writes the records and applies them to memory only if they were written,
+// so memory never has changes the file lost. err is returned after a successful write.
+func (s *File) commitLocked(recs []record, err error) error {
+	if werr := s.write(recs); werr != nil {
+		return werr
+	}
+
+	s.mem.mu.Lock()
+	s.mem.apply(recs)
+	s.mem.mu.Unlock()
+	return err
+}
+
+func (s *File) write(recs []record) error {
+	enc := json.NewEncoder(s.w)
+	for _, rec := range recs {
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("error while writing store file: %w", err)
+		}
+	}
+	s.records += len(recs)
+
+	if err := s.w.Flush(); err != nil {
+		return fmt.Errorf("error while writing store file: %w", err)
+	}
+	return nil
+}
+
+// Compact rewrites the file with only the current items.
+func (s *File) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("error while creating temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	items := s.mem.All()
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for i := range items {
+		if err := enc.Encode(record{Op: opPut, Item: &items[i]}); err != nil {
+			tmp.Close()
+			return fmt.Errorf("error while writing temp file: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error while writing temp file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error while syncing temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error while closing temp file: %w", err)
+	}
+
+	// the old file stays open until the new one replaced it, so a failed rename leaves the store usable
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("error while replacing store file: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("error while opening store file: %w", err)
+	}
+	old := s.f
+	s.f = f
+	s.w = bufio.NewWriter(f)
+	s.records = len(items)
+
+	if err := old.Close(); err != nil {
+		return fmt.Errorf("error while closing replaced store file: %w", err)
+	}
+	return nil
+}
+
+// Garbage returns the number of records in the file not needed for the current items.
+func (s *File) Garbage() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.records - s.mem.Len()
+}
+
+func (s *File) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.w.Flush(); err != nil {
+		return fmt.Errorf("error while writing store file: %w", err)
+	}
+	if err := s.f.Sync(); err != nil {
+		return fmt.Errorf("error while syncing store file: %w", err)
+	}
+	return s.f.Close()
+}
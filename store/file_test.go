@@ -0,0 +1,135 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_Reopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.jsonl")
+
+	s, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, s.Put(testItems()...))
+	require.NoError(t, s.Delete("2"))
+	require.NoError(t, s.ApplyActions(pocket.Actions{
+		&pocket.ActionFavorite{Action: pocket.ActionFavoriteType, ItemID: 1},
+	}))
+	require.Equal(t, 3, s.Garbage())
+	require.NoError(t, s.Close())
+
+	s, err = Open(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "3"}, ids(s.All()))
+	item, _ := s.Get("1")
+	require.True(t, item.IsFavorite())
+
+	require.NoError(t, s.Compact())
+	require.Equal(t, 0, s.Garbage())
+	require.NoError(t, s.Put(pocket.RetrieveListItem{ItemID: "4"}))
+	require.NoError(t, s.Close())
+
+	s, err = Open(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "3", "4"}, ids(s.All()))
+	require.NoError(t, s.Close())
+}
+
+func TestFile_PartialLastLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.jsonl")
+	data := `{"op":"put","item":{"item_id":"1"}}` + "\n" + `{"op":"put","item":{"item_`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+
+	s, err := Open(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1"}, ids(s.All()))
+	require.NoError(t, s.Put(pocket.RetrieveListItem{ItemID: "2"}))
+	require.NoError(t, s.Close())
+
+	s, err = Open(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2"}, ids(s.All()))
+	require.NoError(t, s.Close())
+}
+
+func TestFile_CorruptLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.jsonl")
+	data := `{"op":"put","item":{"item_id":"1"}}` + "\n" +
+		`{"op":"put","item":{"item_` + "\n" +
+		`{"op":"put","item":{"item_id":"2"}}` + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+
+	_, err := Open(path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line 2")
+
+	// the file is left as it was
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, data, string(got))
+}
+
+func TestFile_WriteError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.jsonl")
+
+	s, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, s.Put(pocket.RetrieveListItem{ItemID: "1"}))
+	require.NoError(t, s.f.Close())
+
+	require.Error(t, s.Put(pocket.RetrieveListItem{ItemID: "2"}))
+	require.Error(t, s.Delete("1"))
+	require.Error(t, s.ApplyActions(pocket.Actions{
+		&pocket.ActionArchive{Action: pocket.ActionArchiveType, ItemID: 1},
+	}))
+	require.Error(t, s.ApplyDelta(&pocket.Delta{Full: true}))
+
+	// nothing lost by the file was applied
+	require.Equal(t, []string{"1"}, ids(s.All()))
+	item, _ := s.Get("1")
+	require.Empty(t, item.Status)
+}
+
+func TestFile_ActionOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.jsonl")
+
+	s, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, s.Put(pocket.RetrieveListItem{ItemID: "1"}, pocket.RetrieveListItem{ItemID: "2"}))
+	require.NoError(t, s.ApplyActions(pocket.Actions{
+		&pocket.ActionFavorite{Action: pocket.ActionFavoriteType, ItemID: 1},
+		&pocket.ActionDelete{Action: pocket.ActionDeleteType, ItemID: 1},
+		&pocket.ActionArchive{Action: pocket.ActionArchiveType, ItemID: 2},
+		&pocket.ActionFavorite{Action: pocket.ActionFavoriteType, ItemID: 2},
+	}))
+	require.NoError(t, s.Close())
+
+	s, err = Open(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"2"}, ids(s.All()))
+	item, _ := s.Get("2")
+	require.Equal(t, pocket.ItemStatusArchived, item.Status)
+	require.True(t, item.IsFavorite())
+	require.NoError(t, s.Close())
+}
+
+func TestFile_CompactRenameError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.jsonl")
+
+	s, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, s.Put(pocket.RetrieveListItem{ItemID: "1"}))
+
+	// the temp file can't replace a non-empty directory
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, os.MkdirAll(filepath.Join(path, "dir"), 0o755))
+
+	require.Error(t, s.Compact())
+	require.NoError(t, s.Put(pocket.RetrieveListItem{ItemID: "2"}))
+	require.Equal(t, []string{"1", "2"}, ids(s.All()))
+	require.NoError(t, s.Close())
+}
@@ -0,0 +1,347 @@
+// Package store keeps a local mirror of the user's list.
+package store
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+// Store holds items keyed by item_id and indexed by tag, domain and status.
+type Store interface {
+	Get(id string) (pocket.RetrieveListItem, bool)
+	All() []pocket.RetrieveListItem
+	ByTag(tag string) []pocket.RetrieveListItem
+	ByDomain(domain string) []pocket.RetrieveListItem
+	ByStatus(status string) []pocket.RetrieveListItem
+	Len() int
+
+	Put(items ...pocket.RetrieveListItem) error
+	Delete(ids ...string) error
+	// ApplyDelta applies changes pulled by pocket.Syncer
+	ApplyDelta(d *pocket.Delta) error
+	// ApplyActions applies actions sent to Modify, so the store is up to date before the next sync
+	ApplyActions(actions pocket.Actions) error
+
+	Close() error
+}
+
+type set map[string]struct{}
+
+// Memory is a Store keeping items in memory.
+type Memory struct {
+	mu       sync.RWMutex
+	items    map[string]pocket.RetrieveListItem
+	byTag    map[string]set
+	byDomain map[string]set
+	byStatus map[string]set
+}
+
+var _ Store = (*Memory)(nil)
+
+func NewMemory() *Memory {
+	return &Memory{
+		items:    make(map[string]pocket.RetrieveListItem),
+		byTag:    make(map[string]set),
+		byDomain: make(map[string]set),
+		byStatus: make(map[string]set),
+	}
+}
+
+func (m *Memory) Get(id string) (pocket.RetrieveListItem, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	item, ok := m.items[id]
+	return item, ok
+}
+
+// All returns items sorted by item_id.
+func (m *Memory) All() []pocket.RetrieveListItem {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ids := make(set, len(m.items))
+	for id := range m.items {
+		ids[id] = struct{}{}
+	}
+	return m.collect(ids)
+}
+
+func (m *Memory) ByTag(tag string) []pocket.RetrieveListItem {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.collect(m.byTag[tag])
+}
+
+func (m *Memory) ByDomain(domain string) []pocket.RetrieveListItem {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.collect(m.byDomain[strings.ToLower(domain)])
+}
+
+func (m *Memory) ByStatus(status string) []pocket.RetrieveListItem {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.collect(m.byStatus[status])
+}
+
+func (m *Memory) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.items)
+}
+
+func (m *Memory) Put(items ...pocket.RetrieveListItem) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, item := range items {
+		m.put(item)
+	}
+	return nil
+}
+
+func (m *Memory) Delete(ids ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		m.remove(id)
+	}
+	return nil
+}
+
+func (m *Memory) ApplyDelta(d *pocket.Delta) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.apply(m.deltaRecords(d))
+	return nil
+}
+
+func (m *Memory) ApplyActions(actions pocket.Actions) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	recs, err := m.actionRecords(actions)
+	m.apply(recs)
+	return err
+}
+
+func (m *Memory) Close() error {
+	return nil
+}
+
+// apply puts and deletes items of the records in order.
+func (m *Memory) apply(recs []record) {
+	for _, rec := range recs {
+		switch rec.Op {
+		case opPut:
+			if rec.Item != nil {
+				m.put(*rec.Item)
+			}
+		case opDelete:
+			m.remove(rec.ID)
+		}
+	}
+}
+
+// deltaRecords returns the records applying the delta, without changing the store.
+func (m *Memory) deltaRecords(d *pocket.Delta) []record {
+	var recs []record
+	if d.Full {
+		for id := range m.items {
+			recs = append(recs, record{Op: opDelete, ID: id})
+		}
+	}
+	for _, items := range [][]pocket.RetrieveListItem{d.Added, d.Updated, d.Archived} {
+		for i := range items {
+			recs = append(recs, record{Op: opPut, Item: &items[i]})
+		}
+	}
+	for _, item := range d.Deleted {
+		recs = append(recs, record{Op: opDelete, ID: item.ItemID})
+	}
+	return recs
+}
+
+// actionRecords returns the records applying the actions, without changing the store.
+// Records of the actions before an unparsable one are returned with the error.
+func (m *Memory) actionRecords(actions pocket.Actions) ([]record, error) {
+	var recs []record
+	// items changed by earlier actions, nil for deleted items
+	changed := map[string]*pocket.RetrieveListItem{}
+	lookup := func(id string) (pocket.RetrieveListItem, bool) {
+		if item, ok := changed[id]; ok {
+			if item == nil {
+				return pocket.RetrieveListItem{}, false
+			}
+			return *item, true
+		}
+		item, ok := m.items[id]
+		return item, ok
+	}
+	put := func(item pocket.RetrieveListItem) {
+		changed[item.ItemID] = &item
+		recs = append(recs, record{Op: opPut, Item: &item})
+	}
+
+	for _, a := range actions {
+		f, err := pocket.ParseAction(a)
+		if err != nil {
+			return recs, err
+		}
+
+		t := f.Time
+		if t == 0 {
+			t = time.Now().Unix()
+		}
+		updated := strconv.FormatInt(t, 10)
+
+		switch f.Action {
+		case pocket.ActionAddType:
+			// the item id is known only after the next sync
+			continue
+		case pocket.ActionDeleteType:
+			id := strconv.FormatInt(f.ItemID, 10)
+			if _, ok := lookup(id); ok {
+				changed[id] = nil
+				recs = append(recs, record{Op: opDelete, ID: id})
+			}
+			continue
+		case pocket.ActionTagRenameType, pocket.ActionTagDeleteType:
+			// actions never add items, so every item is in m.items
+			for id := range m.items {
+				item, ok := lookup(id)
+				if !ok || !item.HasTag(f.OldTag) && !item.HasTag(f.Tag) {
+					continue
+				}
+				tags := copyTags(item.Tags)
+				if f.Action == pocket.ActionTagRenameType {
+					delete(tags, f.OldTag)
+					tags[f.NewTag] = pocket.Tag{ItemID: id, Tag: f.NewTag}
+				} else {
+					delete(tags, f.Tag)
+				}
+				item.Tags = tags
+				item.TimeUpdated = updated
+				put(item)
+			}
+			continue
+		}
+
+		id := strconv.FormatInt(f.ItemID, 10)
+		item, ok := lookup(id)
+		if !ok {
+			continue
+		}
+
+		switch f.Action {
+		case pocket.ActionArchiveType:
+			item.Status = pocket.ItemStatusArchived
+			item.TimeRead = updated
+		case pocket.ActionReaddType:
+			item.Status = pocket.ItemStatusUnread
+		case pocket.ActionFavoriteType:
+			item.Favorite = "1"
+			item.TimeFavorited = updated
+		case pocket.ActionUnfavoriteType:
+			item.Favorite = "0"
+		case pocket.ActionTagsAddType, pocket.ActionTagsRemoveType, pocket.ActionTagsReplaceType, pocket.ActionTagsClearType:
+			item.Tags = applyTags(id, item.Tags, f)
+		}
+
+		item.TimeUpdated = updated
+		put(item)
+	}
+
+	return recs, nil
+}
+
+func applyTags(id string, current map[string]pocket.Tag, f pocket.ActionFields) map[string]pocket.Tag {
+	tags := copyTags(current)
+	if f.Action == pocket.ActionTagsReplaceType || f.Action == pocket.ActionTagsClearType {
+		tags = map[string]pocket.Tag{}
+	}
+
+	for _, tag := range strings.Split(f.Tags, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if f.Action == pocket.ActionTagsRemoveType {
+			delete(tags, tag)
+		} else {
+			tags[tag] = pocket.Tag{ItemID: id, Tag: tag}
+		}
+	}
+
+	return tags
+}
+
+func copyTags(tags map[string]pocket.Tag) map[string]pocket.Tag {
+	res := make(map[string]pocket.Tag, len(tags))
+	for k, v := range tags {
+		res[k] = v
+	}
+	return res
+}
+
+func (m *Memory) put(item pocket.RetrieveListItem) {
+	m.remove(item.ItemID)
+
+	m.items[item.ItemID] = item
+	for tag := range item.Tags {
+		add(m.byTag, tag, item.ItemID)
+	}
+	add(m.byDomain, item.Domain(), item.ItemID)
+	add(m.byStatus, item.Status, item.ItemID)
+}
+
+func (m *Memory) remove(id string) {
+	item, ok := m.items[id]
+	if !ok {
+		return
+	}
+
+	delete(m.items, id)
+	for tag := range item.Tags {
+		del(m.byTag, tag, id)
+	}
+	del(m.byDomain, item.Domain(), id)
+	del(m.byStatus, item.Status, id)
+}
+
+func (m *Memory) collect(ids set) []pocket.RetrieveListItem {
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+
+	items := make([]pocket.RetrieveListItem, 0, len(sorted))
+	for _, id := range sorted {
+		items = append(items, m.items[id])
+	}
+	return items
+}
+
+func add(index map[string]set, key, id string) {
+	s, ok := index[key]
+	if !ok {
+		s = make(set)
+		index[key] = s
+	}
+	s[id] = struct{}{}
+}
+
+func del(index map[string]set, key, id string) {
+	s, ok := index[key]
+	if !ok {
+		return
+	}
+	delete(s, id)
+	if len(s) == 0 {
+		delete(index, key)
+	}
+}
@@ -0,0 +1,118 @@
+package store
+
+import (
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func testItems() []pocket.RetrieveListItem {
+	return []pocket.RetrieveListItem{
+		{
+			ItemID:      "1",
+			Status:      pocket.ItemStatusUnread,
+			ResolvedURL: "https://www.github.com/golang/go",
+			Tags:        map[string]pocket.Tag{"go": {ItemID: "1", Tag: "go"}},
+		},
+		{
+			ItemID:      "2",
+			Status:      pocket.ItemStatusArchived,
+			ResolvedURL: "https://go.dev/blog",
+			Tags: map[string]pocket.Tag{
+				"go":   {ItemID: "2", Tag: "go"},
+				"blog": {ItemID: "2", Tag: "blog"},
+			},
+		},
+		{
+			ItemID:   "3",
+			Status:   pocket.ItemStatusUnread,
+			GivenURL: "https://github.com/stretchr/testify",
+		},
+	}
+}
+
+func ids(items []pocket.RetrieveListItem) []string {
+	res := []string{}
+	for _, item := range items {
+		res = append(res, item.ItemID)
+	}
+	return res
+}
+
+func TestMemory_Indexes(t *testing.T) {
+	s := NewMemory()
+	require.NoError(t, s.Put(testItems()...))
+
+	require.Equal(t, 3, s.Len())
+	require.Equal(t, []string{"1", "2", "3"}, ids(s.All()))
+	require.Equal(t, []string{"1", "2"}, ids(s.ByTag("go")))
+	require.Equal(t, []string{"2"}, ids(s.ByTag("blog")))
+	require.Equal(t, []string{"1", "3"}, ids(s.ByDomain("GitHub.com")))
+	require.Equal(t, []string{"1", "3"}, ids(s.ByStatus(pocket.ItemStatusUnread)))
+
+	require.NoError(t, s.Delete("1"))
+	require.Equal(t, []string{"2"}, ids(s.ByTag("go")))
+	require.Equal(t, []string{"3"}, ids(s.ByDomain("github.com")))
+}
+
+func TestMemory_ApplyDelta(t *testing.T) {
+	s := NewMemory()
+	require.NoError(t, s.ApplyDelta(&pocket.Delta{Full: true, Added: testItems()}))
+	require.Equal(t, 3, s.Len())
+
+	archived := testItems()[0]
+	archived.Status = pocket.ItemStatusArchived
+	require.NoError(t, s.ApplyDelta(&pocket.Delta{
+		Archived: []pocket.RetrieveListItem{archived},
+		Deleted:  []pocket.RetrieveListItem{{ItemID: "3", Status: pocket.ItemStatusDeleted}},
+	}))
+	require.Equal(t, []string{"1", "2"}, ids(s.ByStatus(pocket.ItemStatusArchived)))
+	_, ok := s.Get("3")
+	require.False(t, ok)
+
+	require.NoError(t, s.ApplyDelta(&pocket.Delta{Full: true, Added: testItems()[2:]}))
+	require.Equal(t, []string{"3"}, ids(s.All()))
+}
+
+func TestMemory_ApplyActions(t *testing.T) {
+	s := NewMemory()
+	require.NoError(t, s.Put(testItems()...))
+
+	require.NoError(t, s.ApplyActions(pocket.Actions{
+		&pocket.ActionArchive{Action: pocket.ActionArchiveType, ItemID: 1, Time: 100},
+		&pocket.ActionFavorite{Action: pocket.ActionFavoriteType, ItemID: 3},
+		&pocket.ActionTagsAdd{Action: pocket.ActionTagsAddType, ItemID: 3, Tags: "testing, go"},
+		&pocket.ActionTagRename{Action: pocket.ActionTagRenameType, OldTag: "go", NewTag: "golang"},
+		&pocket.ActionTagDelete{Action: pocket.ActionTagDeleteType, Tag: "blog"},
+		&pocket.ActionDelete{Action: pocket.ActionDeleteType, ItemID: 2},
+		&pocket.ActionAdd{Action: pocket.ActionAddType, Url: "https://example.com"},
+	}))
+
+	item, ok := s.Get("1")
+	require.True(t, ok)
+	require.Equal(t, pocket.ItemStatusArchived, item.Status)
+	require.Equal(t, "100", item.TimeRead)
+
+	item, _ = s.Get("3")
+	require.True(t, item.IsFavorite())
+	require.Equal(t, []string{"golang", "testing"}, item.TagNames())
+
+	_, ok = s.Get("2")
+	require.False(t, ok)
+	require.Empty(t, s.ByTag("go"))
+	require.Empty(t, s.ByTag("blog"))
+	require.Equal(t, []string{"1", "3"}, ids(s.ByTag("golang")))
+
+	require.NoError(t, s.ApplyActions(pocket.Actions{
+		&pocket.ActionTagsReplace{Action: pocket.ActionTagsReplaceType, ItemID: 3, Tags: "read"},
+	}))
+	item, _ = s.Get("3")
+	require.Equal(t, []string{"read"}, item.TagNames())
+
+	require.NoError(t, s.ApplyActions(pocket.Actions{
+		&pocket.ActionTagsClear{Action: pocket.ActionTagsClearType, ItemID: 3},
+	}))
+	item, _ = s.Get("3")
+	require.Empty(t, item.TagNames())
+}
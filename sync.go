@@ -0,0 +1,201 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// CursorStore keeps the since cursor between Syncer runs.
+type CursorStore interface {
+	// LoadCursor returns ok=false if there is no cursor yet
+	LoadCursor(ctx context.Context) (since int64, ok bool, err error)
+	SaveCursor(ctx context.Context, since int64) error
+}
+
+type MemoryCursorStore struct {
+	mu    sync.Mutex
+	since int64
+	ok    bool
+}
+
+func (s *MemoryCursorStore) LoadCursor(context.Context) (int64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.since, s.ok, nil
+}
+
+func (s *MemoryCursorStore) SaveCursor(_ context.Context, since int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.since, s.ok = since, true
+	return nil
+}
+
+// FileCursorStore keeps the cursor in a file. The file is replaced atomically.
+type FileCursorStore struct {
+	path string
+}
+
+func NewFileCursorStore(path string) *FileCursorStore {
+	return &FileCursorStore{path: path}
+}
+
+type fileCursor struct {
+	Since int64 `json:"since"`
+}
+
+func (s *FileCursorStore) LoadCursor(context.Context) (int64, bool, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("error while reading cursor file: %w", err)
+	}
+
+	c := fileCursor{}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return 0, false, fmt.Errorf("error while unmarshalling cursor file: %w", err)
+	}
+	return c.Since, true, nil
+}
+
+func (s *FileCursorStore) SaveCursor(_ context.Context, since int64) error {
+	data, err := json.Marshal(fileCursor{Since: since})
+	if err != nil {
+		return fmt.Errorf("error while marshalling cursor: %w", err)
+	}
+	return writeFileAtomic(s.path, data)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("error while creating temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error while writing temp file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error while syncing temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error while closing temp file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error while replacing file: %w", err)
+	}
+	return nil
+}
+
+// Delta is the set of items changed since the previous sync.
+type Delta struct {
+	Since int64 // cursor of the next sync
+	Full  bool  // the delta is the whole list, there was no cursor
+
+	Added    []RetrieveListItem // unread items added since the previous sync
+	Updated  []RetrieveListItem // unread items changed since the previous sync
+	Archived []RetrieveListItem // archived items, newly or changed while in the archive
+	Deleted  []RetrieveListItem // deleted items, only item_id and status are set
+}
+
+func (d *Delta) Empty() bool {
+	return len(d.Added) == 0 && len(d.Updated) == 0 && len(d.Archived) == 0 && len(d.Deleted) == 0
+}
+
+// Syncer pulls changes of the list with the since cursor of Retrieve.
+// The first run pulls the whole list, next runs pull only changes.
+type Syncer struct {
+	client Client
+	store  CursorStore
+}
+
+func NewSyncer(c Client, store CursorStore) *Syncer {
+	return &Syncer{
+		client: c,
+		store:  store,
+	}
+}
+
+// Run pulls the delta and passes it to apply. The cursor is saved only if apply succeeds,
+// so an interrupted run is repeated from the same cursor next time. apply may be nil.
+func (s *Syncer) Run(ctx context.Context, apply func(ctx context.Context, d *Delta) error) (*Delta, error) {
+	since, ok, err := s.store.LoadCursor(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error while loading cursor: %w", err)
+	}
+
+	in := &RetrieveInput{
+		State:      All,
+		DetailType: Complete,
+	}
+	if ok {
+		in.Since = &since
+	}
+
+	res, err := s.client.Retrieve(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	d := newDelta(res, since, !ok)
+
+	if apply != nil {
+		if err := apply(ctx, d); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.store.SaveCursor(ctx, d.Since); err != nil {
+		return nil, fmt.Errorf("error while saving cursor: %w", err)
+	}
+
+	return d, nil
+}
+
+func newDelta(res *RetrieveResponse, since int64, full bool) *Delta {
+	d := &Delta{
+		Since: int64(res.Since),
+		Full:  full,
+	}
+
+	ids := make([]string, 0, len(res.List))
+	for id := range res.List {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		item := res.List[id]
+		if item.ItemID == "" {
+			item.ItemID = id
+		}
+
+		switch item.Status {
+		case ItemStatusDeleted:
+			d.Deleted = append(d.Deleted, item)
+		case ItemStatusArchived:
+			d.Archived = append(d.Archived, item)
+		default:
+			added, _ := strconv.ParseInt(item.TimeAdded, 10, 64)
+			if full || added >= since {
+				d.Added = append(d.Added, item)
+			} else {
+				d.Updated = append(d.Updated, item)
+			}
+		}
+	}
+
+	return d
+}
@@ -0,0 +1,110 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func syncHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		req := retrieveRequest{}
+		require.NoError(t, json.Unmarshal(data, &req))
+		require.Equal(t, All, req.State)
+		require.Equal(t, Complete, req.DetailType)
+
+		resp := `{"status":1,"since":100,"list":{
+			"1":{"item_id":"1","status":"0","time_added":"50"},
+			"2":{"item_id":"2","status":"1","time_added":"60"}}}`
+		switch {
+		case req.Since != nil && *req.Since == 200:
+			// Pocket sends an empty array when nothing changed
+			resp = `{"status":1,"since":200,"list":[]}`
+		case req.Since != nil:
+			require.Equal(t, int64(100), *req.Since)
+			resp = `{"status":1,"since":200,"list":{
+				"1":{"item_id":"1","status":"0","time_added":"50"},
+				"2":{"item_id":"2","status":"2"},
+				"3":{"item_id":"3","status":"0","time_added":"150"},
+				"4":{"item_id":"4","status":"1","time_added":"160"}}}`
+		}
+		_, err = w.Write([]byte(resp))
+		require.NoError(t, err)
+	}
+}
+
+func itemIDs(items []RetrieveListItem) []string {
+	ids := []string{}
+	for _, item := range items {
+		ids = append(ids, item.ItemID)
+	}
+	return ids
+}
+
+func TestSyncer_Run(t *testing.T) {
+	srv := httptest.NewServer(syncHandler(t))
+	defer srv.Close()
+
+	p := New(consumerKey).WithBaseUrl(srv.URL)
+	store := NewFileCursorStore(filepath.Join(t.TempDir(), "cursor.json"))
+	s := NewSyncer(p, store)
+
+	d, err := s.Run(context.Background(), nil)
+	require.NoError(t, err)
+	require.True(t, d.Full)
+	require.Equal(t, int64(100), d.Since)
+	require.Equal(t, []string{"1"}, itemIDs(d.Added))
+	require.Equal(t, []string{"2"}, itemIDs(d.Archived))
+
+	errApply := errors.New("apply error")
+	_, err = s.Run(context.Background(), func(ctx context.Context, d *Delta) error {
+		return errApply
+	})
+	require.ErrorIs(t, err, errApply)
+
+	since, ok, err := store.LoadCursor(context.Background())
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, int64(100), since)
+
+	d, err = s.Run(context.Background(), func(ctx context.Context, d *Delta) error {
+		return nil
+	})
+	require.NoError(t, err)
+	require.False(t, d.Full)
+	require.Equal(t, []string{"3"}, itemIDs(d.Added))
+	require.Equal(t, []string{"1"}, itemIDs(d.Updated))
+	require.Equal(t, []string{"4"}, itemIDs(d.Archived))
+	require.Equal(t, []string{"2"}, itemIDs(d.Deleted))
+
+	since, _, err = store.LoadCursor(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(200), since)
+
+	d, err = s.Run(context.Background(), nil)
+	require.NoError(t, err)
+	require.False(t, d.Full)
+	require.Empty(t, d.Added)
+	require.Empty(t, d.Updated)
+	require.Empty(t, d.Archived)
+	require.Empty(t, d.Deleted)
+	require.Equal(t, int64(200), d.Since)
+}
+
+func TestFileCursorStore_NoCursor(t *testing.T) {
+	store := NewFileCursorStore(filepath.Join(t.TempDir(), "cursor.json"))
+
+	_, ok, err := store.LoadCursor(context.Background())
+	require.NoError(t, err)
+	require.False(t, ok)
+}
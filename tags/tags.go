@@ -0,0 +1,368 @@
+// Package tags finds near duplicate and rarely used tags and cleans them up.
+package tags
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+)
+
+// spelling variants are looked for only among tags at least this long,
+// short tags like go and js are too close to each other
+const minSpellingLength = 5
+
+// Usage is the number of items with a tag.
+type Usage struct {
+	Tag   string
+	Count int
+}
+
+// Count returns usage of tags in not deleted items ordered from the most used.
+// Retrieve items with pocket.Complete details to get their tags.
+func Count(items []pocket.RetrieveListItem) []Usage {
+	counts := map[string]int{}
+	for _, item := range items {
+		if item.Status == pocket.ItemStatusDeleted {
+			continue
+		}
+		for tag := range item.Tags {
+			counts[tag]++
+		}
+	}
+
+	usage := make([]Usage, 0, len(counts))
+	for tag, n := range counts {
+		usage = append(usage, Usage{Tag: tag, Count: n})
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Count != usage[j].Count {
+			return usage[i].Count > usage[j].Count
+		}
+		return usage[i].Tag < usage[j].Tag
+	})
+	return usage
+}
+
+// Kind is the reason of a proposal.
+type Kind string
+
+const (
+	Case      Kind = "case"      // Go and go
+	Separator Kind = "separator" // go-lang and golang
+	Plural    Kind = "plural"    // article and articles
+	Spelling  Kind = "spelling"  // javascript and javascirpt
+	Rare      Kind = "rare"      // used by fewer items than Options.MinCount
+)
+
+// Proposal is a change of a tag.
+type Proposal struct {
+	Tag   string
+	Into  string // the tag Tag is merged into, empty if Tag is deleted
+	Kind  Kind
+	Count int // number of items with Tag
+}
+
+func (p Proposal) String() string {
+	if p.Into == "" {
+		return fmt.Sprintf("delete %q (%s, %d items)", p.Tag, p.Kind, p.Count)
+	}
+	return fmt.Sprintf("merge %q into %q (%s, %d items)", p.Tag, p.Into, p.Kind, p.Count)
+}
+
+type Options struct {
+	MaxDistance int // max edit distance of spelling variants, spelling variants are not looked for if zero
+	MinCount    int // tags used by fewer items are proposed for deletion, none are if zero
+}
+
+// Propose groups variants of tags and proposes to merge every variant into the most used tag of its group.
+// Variants differ in case, separators (-, _, ., /, space), plural s and, with Options.MaxDistance, spelling
+// of the most used variant. Synonyms like go and golang are not variants.
+func Propose(usage []Usage, opts Options) []Proposal {
+	// group by the normalized tag
+	var groups [][]Usage
+	byKey := map[string]int{}
+	var keys []string
+	for _, u := range usage {
+		k := key(u.Tag)
+		i, ok := byKey[k]
+		if !ok {
+			i = len(groups)
+			byKey[k] = i
+			groups = append(groups, nil)
+			keys = append(keys, k)
+		}
+		groups[i] = append(groups[i], u)
+	}
+
+	// join groups with keys close to the key of a more used group; every group is compared with the
+	// first group of a join only, so chains like javascript, javascirpt, javscirpt are not joined end to end
+	order := make([]int, len(groups))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return total(groups[order[i]]) > total(groups[order[j]])
+	})
+	joined := map[int][]Usage{}
+	var heads []int
+	for _, i := range order {
+		head := i
+		if opts.MaxDistance > 0 {
+			for _, h := range heads {
+				if spellingVariants(keys[h], keys[i], opts.MaxDistance) {
+					head = h
+					break
+				}
+			}
+		}
+		if head == i {
+			heads = append(heads, i)
+		}
+		joined[head] = append(joined[head], groups[i]...)
+	}
+
+	var proposals []Proposal
+	merged := map[string]bool{}
+	for _, g := range joined {
+		if len(g) < 2 {
+			continue
+		}
+		into := canonical(g)
+		for _, u := range g {
+			if u.Tag == into.Tag {
+				continue
+			}
+			proposals = append(proposals, Proposal{Tag: u.Tag, Into: into.Tag, Kind: kindOf(u.Tag, into.Tag), Count: u.Count})
+			merged[u.Tag] = true
+			merged[into.Tag] = true
+		}
+	}
+	sort.Slice(proposals, func(i, j int) bool {
+		if proposals[i].Into != proposals[j].Into {
+			return proposals[i].Into < proposals[j].Into
+		}
+		return proposals[i].Tag < proposals[j].Tag
+	})
+
+	var rare []Proposal
+	for _, u := range usage {
+		if u.Count < opts.MinCount && !merged[u.Tag] {
+			rare = append(rare, Proposal{Tag: u.Tag, Kind: Rare, Count: u.Count})
+		}
+	}
+	sort.Slice(rare, func(i, j int) bool {
+		return rare[i].Tag < rare[j].Tag
+	})
+
+	return append(proposals, rare...)
+}
+
+// Actions returns tag_rename actions for merges and tag_delete actions for deletions.
+func Actions(proposals []Proposal) pocket.Actions {
+	now := time.Now().Unix()
+
+	actions := make(pocket.Actions, 0, len(proposals))
+	for _, p := range proposals {
+		if p.Into == "" {
+			actions = append(actions, &pocket.ActionTagDelete{
+				Action: pocket.ActionTagDeleteType,
+				Tag:    p.Tag,
+				Time:   now,
+			})
+			continue
+		}
+		actions = append(actions, &pocket.ActionTagRename{
+			Action: pocket.ActionTagRenameType,
+			OldTag: p.Tag,
+			NewTag: p.Into,
+			Time:   now,
+		})
+	}
+	return actions
+}
+
+// Report is the result of Apply.
+type Report struct {
+	Proposals []Proposal
+	Actions   pocket.Actions
+	Response  *pocket.ModifyResponse // nil for a dry run
+	DryRun    bool
+}
+
+func (r *Report) String() string {
+	sb := strings.Builder{}
+	for _, p := range r.Proposals {
+		sb.WriteString(p.String() + "\n")
+	}
+	switch {
+	case len(r.Proposals) == 0:
+		sb.WriteString("nothing to clean up\n")
+	case r.DryRun:
+		fmt.Fprintf(&sb, "dry run, %d actions not sent\n", len(r.Actions))
+	default:
+		fmt.Fprintf(&sb, "%d actions sent\n", len(r.Actions))
+	}
+	return sb.String()
+}
+
+// Apply sends the actions of the proposals with one Modify call, a dry run only reports them.
+func Apply(ctx context.Context, c pocket.Client, proposals []Proposal, dryRun bool) (*Report, error) {
+	r := &Report{
+		Proposals: proposals,
+		Actions:   Actions(proposals),
+		DryRun:    dryRun,
+	}
+	if dryRun || len(r.Actions) == 0 {
+		return r, nil
+	}
+
+	res, err := c.Modify(ctx, r.Actions)
+	if err != nil {
+		return r, fmt.Errorf("error while applying tag changes: %w", err)
+	}
+	r.Response = res
+	return r, nil
+}
+
+func total(g []Usage) int {
+	n := 0
+	for _, u := range g {
+		n += u.Count
+	}
+	return n
+}
+
+// canonical returns the most used tag, preferring lower case, shorter and then alphabetically first tags.
+func canonical(g []Usage) Usage {
+	best := g[0]
+	for _, u := range g[1:] {
+		switch {
+		case u.Count != best.Count:
+			if u.Count > best.Count {
+				best = u
+			}
+		case isLower(u.Tag) != isLower(best.Tag):
+			if isLower(u.Tag) {
+				best = u
+			}
+		case len(u.Tag) != len(best.Tag):
+			if len(u.Tag) < len(best.Tag) {
+				best = u
+			}
+		case u.Tag < best.Tag:
+			best = u
+		}
+	}
+	return best
+}
+
+func kindOf(tag, into string) Kind {
+	switch {
+	case strings.EqualFold(tag, into):
+		return Case
+	case compact(tag) == compact(into):
+		return Separator
+	case key(tag) == key(into):
+		return Plural
+	}
+	return Spelling
+}
+
+// key returns the tag in lower case without separators and the plural ending.
+func key(tag string) string {
+	return singular(compact(tag))
+}
+
+func compact(tag string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', '.', '/', ' ':
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, strings.TrimSpace(tag))
+}
+
+// notPlural are words ending in s which are not plurals of a word without it
+var notPlural = map[string]bool{
+	"news":       true,
+	"ios":        true,
+	"macos":      true,
+	"series":     true,
+	"species":    true,
+	"windows":    true,
+	"kubernetes": true,
+}
+
+func singular(s string) string {
+	switch {
+	case notPlural[s] || strings.HasSuffix(s, "us") || strings.HasSuffix(s, "is"):
+		// news, status, analysis
+		return s
+	case len(s) > 4 && strings.HasSuffix(s, "ies"):
+		return strings.TrimSuffix(s, "ies") + "y"
+	case len(s) > 4 && strings.HasSuffix(s, "sses"):
+		return strings.TrimSuffix(s, "es")
+	case len(s) > 3 && strings.HasSuffix(s, "s") && !strings.HasSuffix(s, "ss"):
+		return strings.TrimSuffix(s, "s")
+	}
+	return s
+}
+
+func isLower(s string) bool {
+	return s == strings.ToLower(s)
+}
+
+// spellingVariants reports whether keys are long enough, have no digits and are close enough to be misspellings of each other.
+// Tags with digits like 2023 and 2024 are different on purpose.
+func spellingVariants(a, b string, maxDistance int) bool {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < minSpellingLength || len(rb) < minSpellingLength {
+		return false
+	}
+	if strings.IndexFunc(a, unicode.IsDigit) >= 0 || strings.IndexFunc(b, unicode.IsDigit) >= 0 {
+		return false
+	}
+	if d := len(ra) - len(rb); d > maxDistance || -d > maxDistance {
+		return false
+	}
+	return distance(ra, rb) <= maxDistance
+}
+
+// distance returns the Levenshtein distance with transpositions counted as one edit.
+func distance(a, b []rune) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(minInt(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = minInt(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
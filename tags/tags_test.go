@@ -0,0 +1,170 @@
+package tags
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	pocket "github.com/VladimirStepanov/pocket-golang-sdk"
+	"github.com/VladimirStepanov/pocket-golang-sdk/pocketmock"
+	"github.com/stretchr/testify/require"
+)
+
+func tagged(status string, tags ...string) pocket.RetrieveListItem {
+	item := pocket.RetrieveListItem{Status: status, Tags: map[string]pocket.Tag{}}
+	for _, tag := range tags {
+		item.Tags[tag] = pocket.Tag{Tag: tag}
+	}
+	return item
+}
+
+func TestCount(t *testing.T) {
+	usage := Count([]pocket.RetrieveListItem{
+		tagged(pocket.ItemStatusUnread, "go", "news"),
+		tagged(pocket.ItemStatusArchived, "go"),
+		tagged(pocket.ItemStatusUnread, "rust"),
+		tagged(pocket.ItemStatusDeleted, "rust", "deleted"),
+		tagged(pocket.ItemStatusUnread),
+	})
+
+	require.Equal(t, []Usage{
+		{Tag: "go", Count: 2},
+		{Tag: "news", Count: 1},
+		{Tag: "rust", Count: 1},
+	}, usage)
+}
+
+func TestPropose(t *testing.T) {
+	tests := []struct {
+		name  string
+		usage []Usage
+		opts  Options
+		exp   []Proposal
+	}{
+		{
+			name:  "case",
+			usage: []Usage{{"go", 5}, {"Go", 2}, {"GO", 1}},
+			exp: []Proposal{
+				{Tag: "GO", Into: "go", Kind: Case, Count: 1},
+				{Tag: "Go", Into: "go", Kind: Case, Count: 2},
+			},
+		},
+		{
+			name:  "separators",
+			usage: []Usage{{"go-lang", 3}, {"golang", 1}, {"go_lang", 1}, {"Go Lang", 1}},
+			exp: []Proposal{
+				{Tag: "Go Lang", Into: "go-lang", Kind: Separator, Count: 1},
+				{Tag: "go_lang", Into: "go-lang", Kind: Separator, Count: 1},
+				{Tag: "golang", Into: "go-lang", Kind: Separator, Count: 1},
+			},
+		},
+		{
+			name:  "plurals",
+			usage: []Usage{{"article", 3}, {"articles", 2}, {"stories", 1}, {"story", 1}, {"class", 1}, {"classes", 1}},
+			exp: []Proposal{
+				{Tag: "articles", Into: "article", Kind: Plural, Count: 2},
+				{Tag: "classes", Into: "class", Kind: Plural, Count: 1},
+				{Tag: "stories", Into: "story", Kind: Plural, Count: 1},
+			},
+		},
+		{
+			name:  "not plurals",
+			usage: []Usage{{"news", 3}, {"new", 1}, {"ios", 2}, {"io", 1}, {"series", 1}, {"sery", 1}, {"status", 1}, {"statu", 1}, {"analysis", 1}, {"analysi", 1}},
+		},
+		{
+			name:  "spelling",
+			usage: []Usage{{"javascript", 4}, {"javascirpt", 1}, {"javscript", 1}, {"go", 1}, {"js", 1}, {"2023", 1}, {"2024", 1}},
+			opts:  Options{MaxDistance: 1},
+			exp: []Proposal{
+				{Tag: "javascirpt", Into: "javascript", Kind: Spelling, Count: 1},
+				{Tag: "javscript", Into: "javascript", Kind: Spelling, Count: 1},
+			},
+		},
+		{
+			name:  "spelling is not transitive",
+			usage: []Usage{{"abcdef", 3}, {"abcdeg", 2}, {"abcdgg", 1}, {"abcggg", 1}},
+			opts:  Options{MaxDistance: 1},
+			exp: []Proposal{
+				{Tag: "abcdeg", Into: "abcdef", Kind: Spelling, Count: 2},
+				{Tag: "abcggg", Into: "abcdgg", Kind: Spelling, Count: 1},
+			},
+		},
+		{
+			name:  "synonyms",
+			usage: []Usage{{"go", 3}, {"golang", 2}},
+			opts:  Options{MaxDistance: 1},
+		},
+		{
+			name:  "spelling disabled",
+			usage: []Usage{{"javascript", 4}, {"javascirpt", 1}},
+		},
+		{
+			name:  "rare",
+			usage: []Usage{{"go", 3}, {"Go", 1}, {"misc", 1}, {"todo", 2}},
+			opts:  Options{MinCount: 2},
+			exp: []Proposal{
+				{Tag: "Go", Into: "go", Kind: Case, Count: 1},
+				{Tag: "misc", Kind: Rare, Count: 1},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.exp, Propose(tc.usage, tc.opts))
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	proposals := []Proposal{
+		{Tag: "Go", Into: "go", Kind: Case, Count: 2},
+		{Tag: "misc", Kind: Rare, Count: 1},
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		m := &pocketmock.MockClient{}
+		r, err := Apply(context.Background(), m, proposals, true)
+		require.NoError(t, err)
+		require.Empty(t, m.ModifyCalls())
+		require.Len(t, r.Actions, 2)
+		require.Equal(t, "merge \"Go\" into \"go\" (case, 2 items)\n"+
+			"delete \"misc\" (rare, 1 items)\n"+
+			"dry run, 2 actions not sent\n", r.String())
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		m := &pocketmock.MockClient{
+			ModifyFunc: func(ctx context.Context, actions pocket.Actions) (*pocket.ModifyResponse, error) {
+				return &pocket.ModifyResponse{Status: 1, ActionResult: []interface{}{true, true}}, nil
+			},
+		}
+		r, err := Apply(context.Background(), m, proposals, false)
+		require.NoError(t, err)
+		require.NotNil(t, r.Response)
+
+		calls := m.ModifyCalls()
+		require.Len(t, calls, 1)
+		rename := calls[0].Actions[0].(*pocket.ActionTagRename)
+		require.Equal(t, pocket.ActionTagRenameType, rename.Action)
+		require.Equal(t, "Go", rename.OldTag)
+		require.Equal(t, "go", rename.NewTag)
+		del := calls[0].Actions[1].(*pocket.ActionTagDelete)
+		require.Equal(t, pocket.ActionTagDeleteType, del.Action)
+		require.Equal(t, "misc", del.Tag)
+		require.True(t, strings.HasSuffix(r.String(), "\n2 actions sent\n"))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		m := &pocketmock.MockClient{}
+		_, err := Apply(context.Background(), m, proposals, false)
+		require.True(t, errors.Is(err, pocketmock.ErrNotProgrammed))
+	})
+
+	t.Run("nothing", func(t *testing.T) {
+		r, err := Apply(context.Background(), &pocketmock.MockClient{}, nil, false)
+		require.NoError(t, err)
+		require.Equal(t, "nothing to clean up\n", r.String())
+	})
+}
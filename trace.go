@@ -0,0 +1,56 @@
+package pocket
+
+import (
+	"context"
+	"errors"
+)
+
+// span attributes
+const (
+	AttrEndpoint    = "pocket.endpoint"
+	AttrAttempt     = "pocket.attempt"
+	AttrActionCount = "pocket.action_count"
+	AttrItemCount   = "pocket.item_count"
+	AttrErrorCode   = "pocket.error_code"
+	AttrHttpStatus  = "pocket.http_status"
+)
+
+// span names
+const (
+	SpanModify   = "pocket.Modify"
+	SpanRetrieve = "pocket.Retrieve"
+	SpanRequest  = "pocket.request"
+	SpanAttempt  = "pocket.attempt"
+)
+
+// SpanAttrs are attributes of a span. The client may add attributes known only after
+// the call, e.g. the item count, so adapters should read the map when the span ends.
+type SpanAttrs map[string]interface{}
+
+// Tracer starts a span and returns a context carrying it with a function ending the span.
+type Tracer interface {
+	StartSpan(ctx context.Context, name string, attrs SpanAttrs) (context.Context, func(err error))
+}
+
+type nopTracer struct{}
+
+func (nopTracer) StartSpan(ctx context.Context, _ string, _ SpanAttrs) (context.Context, func(err error)) {
+	return ctx, func(error) {}
+}
+
+func (p *Pocket) startSpan(ctx context.Context, name string, attrs SpanAttrs) (context.Context, func(err error)) {
+	var t Tracer = nopTracer{}
+	if p.tracer != nil {
+		t = p.tracer
+	}
+
+	ctx, end := t.StartSpan(ctx, name, attrs)
+	return ctx, func(err error) {
+		var perr *ErrorPocket
+		if errors.As(err, &perr) {
+			attrs[AttrErrorCode] = perr.Xcode
+			attrs[AttrHttpStatus] = perr.HttpCode
+		}
+		end(err)
+	}
+}
@@ -0,0 +1,98 @@
+package pocket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type spanKey struct{}
+
+type recordedSpan struct {
+	name   string
+	parent string
+	attrs  SpanAttrs
+	err    error
+	ended  bool
+}
+
+type recordTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (rt *recordTracer) StartSpan(ctx context.Context, name string, attrs SpanAttrs) (context.Context, func(err error)) {
+	parent, _ := ctx.Value(spanKey{}).(string)
+	s := &recordedSpan{name: name, parent: parent, attrs: attrs}
+
+	rt.mu.Lock()
+	rt.spans = append(rt.spans, s)
+	rt.mu.Unlock()
+
+	return context.WithValue(ctx, spanKey{}, name), func(err error) {
+		s.err = err
+		s.ended = true
+	}
+}
+
+func TestPocket_Tracer(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch {
+		case r.URL.Path == modifyPath:
+			w.Header().Add("X-Error-Code", xUnauthorized)
+			w.WriteHeader(http.StatusUnauthorized)
+		case calls == 1:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			_, err := w.Write([]byte(`{"status":1,"list":{"1":{"item_id":"1"},"2":{"item_id":"2"}}}`))
+			require.NoError(t, err)
+		}
+	}))
+	defer srv.Close()
+
+	rt := &recordTracer{}
+	p := New(consumerKey).WithBaseUrl(srv.URL).WithTracer(rt).WithRetry(2, time.Millisecond)
+
+	_, err := p.Retrieve(context.Background(), &RetrieveInput{})
+	require.NoError(t, err)
+
+	require.Len(t, rt.spans, 4)
+	retrieve, request, attempt1, attempt2 := rt.spans[0], rt.spans[1], rt.spans[2], rt.spans[3]
+
+	require.Equal(t, SpanRetrieve, retrieve.name)
+	require.Equal(t, 2, retrieve.attrs[AttrItemCount])
+	require.NoError(t, retrieve.err)
+
+	require.Equal(t, SpanRequest, request.name)
+	require.Equal(t, SpanRetrieve, request.parent)
+	require.Equal(t, string(EndpointGet), request.attrs[AttrEndpoint])
+
+	require.Equal(t, SpanAttempt, attempt1.name)
+	require.Equal(t, SpanRequest, attempt1.parent)
+	require.Equal(t, 1, attempt1.attrs[AttrAttempt])
+	require.Equal(t, http.StatusBadGateway, attempt1.attrs[AttrHttpStatus])
+	require.Error(t, attempt1.err)
+	require.Equal(t, 2, attempt2.attrs[AttrAttempt])
+	require.NoError(t, attempt2.err)
+
+	rt.spans = nil
+	_, err = p.Modify(context.Background(), Actions{&ActionArchive{Action: ActionArchiveType}, &ActionDelete{Action: ActionDeleteType}})
+	require.Error(t, err)
+
+	require.Len(t, rt.spans, 3)
+	modify := rt.spans[0]
+	require.Equal(t, SpanModify, modify.name)
+	require.Equal(t, 2, modify.attrs[AttrActionCount])
+	require.Equal(t, xUnauthorized, modify.attrs[AttrErrorCode])
+	require.Error(t, modify.err)
+	for _, s := range rt.spans {
+		require.True(t, s.ended)
+	}
+}
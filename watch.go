@@ -0,0 +1,209 @@
+package pocket
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+const (
+	// the poll interval grows up to maxIdleFactor times while nothing changes
+	maxIdleFactor = 16
+	// used for a non-positive interval, which would poll without a pause
+	defaultWatchInterval = time.Minute
+)
+
+type EventType string
+
+const (
+	ItemAdded     EventType = "added"
+	ItemArchived  EventType = "archived"
+	ItemFavorited EventType = "favorited"
+	ItemTagged    EventType = "tagged"
+	ItemDeleted   EventType = "deleted"
+	// WatchError is emitted when a poll fails, polling goes on
+	WatchError EventType = "error"
+)
+
+type Event struct {
+	Type EventType
+	Item RetrieveListItem
+	Tags []string // tags added to the item, for ItemTagged
+	Err  error    // for WatchError
+}
+
+type watchedItem struct {
+	status   string
+	favorite bool
+	tags     map[string]Tag
+}
+
+// Watch polls Retrieve every interval and emits events about changed items until ctx is done.
+// filter narrows the watched items, its State, DetailType and Since are overridden.
+// The interval doubles while nothing changes, and polling waits for the rate limit reset
+// if Pocket reports no requests left. A non-positive interval means one minute.
+// The channel is closed when ctx is done.
+func (p *Pocket) Watch(ctx context.Context, interval time.Duration, filter *RetrieveInput) <-chan Event {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	events := make(chan Event)
+	go p.watch(ctx, interval, filter, events)
+	return events
+}
+
+func (p *Pocket) watch(ctx context.Context, interval time.Duration, filter *RetrieveInput, events chan<- Event) {
+	defer close(events)
+
+	in := RetrieveInput{}
+	if filter != nil {
+		in = *filter
+	}
+	in.State = All
+	in.DetailType = Complete
+	in.Since = nil
+
+	var (
+		known map[string]watchedItem
+		delay = interval
+	)
+
+	for first := true; ; first = false {
+		if !first && !sleep(ctx, p.rateLimitDelay(delay)) {
+			return
+		}
+
+		res, err := p.Retrieve(ctx, &in)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			if !sendEvent(ctx, events, Event{Type: WatchError, Err: err}) {
+				return
+			}
+			delay = idleDelay(delay, interval)
+			continue
+		}
+
+		since := int64(res.Since)
+		in.Since = &since
+
+		if known == nil {
+			known = make(map[string]watchedItem, len(res.List))
+			for id, item := range res.List {
+				if item.Status != ItemStatusDeleted {
+					known[id] = newWatchedItem(item)
+				}
+			}
+			continue
+		}
+
+		changed := diffItems(known, res.List)
+		for _, e := range changed {
+			if !sendEvent(ctx, events, e) {
+				return
+			}
+		}
+
+		if len(changed) != 0 {
+			delay = interval
+		} else {
+			delay = idleDelay(delay, interval)
+		}
+	}
+}
+
+func newWatchedItem(item RetrieveListItem) watchedItem {
+	return watchedItem{
+		status:   item.Status,
+		favorite: item.IsFavorite(),
+		tags:     item.Tags,
+	}
+}
+
+// diffItems returns events of changed items sorted by item id and updates known items.
+func diffItems(known map[string]watchedItem, list map[string]RetrieveListItem) []Event {
+	ids := make([]string, 0, len(list))
+	for id := range list {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var events []Event
+	for _, id := range ids {
+		item := list[id]
+		prev, ok := known[id]
+
+		if item.Status == ItemStatusDeleted {
+			if ok {
+				events = append(events, Event{Type: ItemDeleted, Item: item})
+				delete(known, id)
+			}
+			continue
+		}
+		known[id] = newWatchedItem(item)
+
+		if !ok {
+			events = append(events, Event{Type: ItemAdded, Item: item})
+		}
+		if item.Status == ItemStatusArchived && (!ok || prev.status != ItemStatusArchived) {
+			events = append(events, Event{Type: ItemArchived, Item: item})
+		}
+		if item.IsFavorite() && (!ok || !prev.favorite) {
+			events = append(events, Event{Type: ItemFavorited, Item: item})
+		}
+
+		var added []string
+		for _, tag := range item.TagNames() {
+			if _, had := prev.tags[tag]; !had {
+				added = append(added, tag)
+			}
+		}
+		if len(added) != 0 {
+			events = append(events, Event{Type: ItemTagged, Item: item, Tags: added})
+		}
+	}
+
+	return events
+}
+
+// rateLimitDelay extends the delay up to the rate limit reset if no requests are left.
+func (p *Pocket) rateLimitDelay(delay time.Duration) time.Duration {
+	for _, rl := range []RateLimit{p.UserRateLimit(), p.KeyRateLimit()} {
+		if !rl.Known() || rl.Remaining > 0 {
+			continue
+		}
+		if wait := time.Until(rl.ResetAt()); wait > delay {
+			delay = wait
+		}
+	}
+	return delay
+}
+
+func idleDelay(delay, interval time.Duration) time.Duration {
+	delay *= 2
+	if max := interval * maxIdleFactor; delay > max {
+		delay = max
+	}
+	return delay
+}
+
+func sendEvent(ctx context.Context, events chan<- Event, e Event) bool {
+	select {
+	case events <- e:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
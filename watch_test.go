@@ -0,0 +1,149 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPocket_Watch(t *testing.T) {
+	responses := []string{
+		`{"status":1,"since":10,"list":{
+			"1":{"item_id":"1","status":"0","favorite":"0"},
+			"2":{"item_id":"2","status":"0","favorite":"0"}}}`,
+		`{"status":1,"since":20,"list":[]}`,
+		`{"status":1,"since":30,"list":{
+			"1":{"item_id":"1","status":"1","favorite":"1","tags":{"go":{"item_id":"1","tag":"go"}}},
+			"2":{"item_id":"2","status":"2"},
+			"3":{"item_id":"3","status":"0","favorite":"0"}}}`,
+	}
+
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		req := retrieveRequest{}
+		require.NoError(t, json.Unmarshal(data, &req))
+		require.Equal(t, "go", req.Tag)
+		require.Equal(t, All, req.State)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if calls == 0 {
+			require.Nil(t, req.Since)
+		} else {
+			require.NotNil(t, req.Since)
+		}
+
+		resp := `{"status":1,"since":30,"list":[]}`
+		if calls < len(responses) {
+			resp = responses[calls]
+		}
+		calls++
+		_, err = w.Write([]byte(resp))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := New(consumerKey).WithBaseUrl(srv.URL)
+	events := p.Watch(ctx, time.Millisecond, &RetrieveInput{Tag: "go"})
+
+	var got []Event
+	for e := range events {
+		got = append(got, e)
+		if len(got) == 5 {
+			cancel()
+		}
+	}
+
+	require.Len(t, got, 5)
+	require.Equal(t, ItemArchived, got[0].Type)
+	require.Equal(t, "1", got[0].Item.ItemID)
+	require.Equal(t, ItemFavorited, got[1].Type)
+	require.Equal(t, ItemTagged, got[2].Type)
+	require.Equal(t, []string{"go"}, got[2].Tags)
+	require.Equal(t, ItemDeleted, got[3].Type)
+	require.Equal(t, "2", got[3].Item.ItemID)
+	require.Equal(t, ItemAdded, got[4].Type)
+	require.Equal(t, "3", got[4].Item.ItemID)
+}
+
+func TestPocket_WatchError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("X-Error-Code", xUnauthorized)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := New(consumerKey).WithBaseUrl(srv.URL).Watch(ctx, time.Millisecond, nil)
+
+	e := <-events
+	require.Equal(t, WatchError, e.Type)
+	require.Error(t, e.Err)
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestPocket_WatchNonPositiveInterval(t *testing.T) {
+	polls := make(chan struct{}, 100)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls <- struct{}{}
+		_, err := w.Write([]byte(`{"status":1,"since":10,"list":[]}`))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		ctx, cancel := context.WithCancel(context.Background())
+		events := New(consumerKey).WithBaseUrl(srv.URL).Watch(ctx, interval, nil)
+
+		select {
+		case <-polls:
+		case <-time.After(5 * time.Second):
+			t.Fatal("watch didn't poll")
+		}
+		// the next poll is a minute away; without the clamp it would follow at once
+		select {
+		case <-polls:
+			t.Fatalf("polled again without a pause for interval %v", interval)
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		cancel()
+		for e := range events {
+			require.NotEqual(t, WatchError, e.Type)
+		}
+	}
+}
+
+func TestIdleDelay(t *testing.T) {
+	require.Equal(t, 2*time.Second, idleDelay(time.Second, time.Second))
+	require.Equal(t, 16*time.Second, idleDelay(16*time.Second, time.Second))
+}
+
+func TestPocket_RateLimitDelay(t *testing.T) {
+	p := New(consumerKey)
+	require.Equal(t, time.Second, p.rateLimitDelay(time.Second))
+
+	p.userLimit.limit = RateLimit{Remaining: 0, Reset: time.Hour, UpdatedAt: time.Now()}
+	require.Greater(t, p.rateLimitDelay(time.Second), 59*time.Minute)
+}